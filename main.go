@@ -118,6 +118,8 @@ It supports various compressed files(gzip, bzip2, zstd, lz4, and xz).
 		oviewer.OverLineStyle = oviewer.ToTcellStyle(config.StyleOverLine)
 		oviewer.MemoryLimit = config.MemoryLimit
 		oviewer.MemoryLimitFile = config.MemoryLimitFile
+		oviewer.Preprocessors = config.Preprocessors
+		oviewer.LessOpen = config.LessOpen
 		if !forceScreen {
 			SetRedirect()
 		}
@@ -478,10 +480,10 @@ func init() {
 	rootCmd.PersistentFlags().BoolVarP(&oviewer.SkipExtract, "skip-extract", "", false, "read compressed files as raw bytes without decompressing")
 
 	// Config.General
-	rootCmd.PersistentFlags().StringP("converter", "", "es", "content processing mode [es|raw|align|wordwrap]")
+	rootCmd.PersistentFlags().StringP("converter", "", "es", "content processing mode [es|raw|align|wordwrap|json|vt|markdown]")
 	_ = viper.BindPFlag("general.Converter", rootCmd.PersistentFlags().Lookup("converter"))
 	_ = rootCmd.RegisterFlagCompletionFunc("converter", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-		return []string{"es\tEscape Sequence", "raw\tRaw output of escape sequences", "align\tAlign Column Widths", "wordwrap\tWord Wrap"}, cobra.ShellCompDirectiveNoFileComp
+		return []string{"es\tEscape Sequence", "raw\tRaw output of escape sequences", "align\tAlign Column Widths", "wordwrap\tWord Wrap", "json\tPretty-print JSON Lines", "vt\tRender as a virtual terminal", "markdown\tRender Markdown"}, cobra.ShellCompDirectiveNoFileComp
 	})
 
 	rootCmd.PersistentFlags().BoolP("align", "l", false, "align the output columns for better readability")
@@ -531,6 +533,12 @@ func init() {
 	rootCmd.PersistentFlags().BoolP("column-rainbow", "", false, "colorize each column with a distinct color")
 	_ = viper.BindPFlag("general.ColumnRainbow", rootCmd.PersistentFlags().Lookup("column-rainbow"))
 
+	rootCmd.PersistentFlags().BoolP("align-stable", "", false, "compute the column widths of align mode over the whole document")
+	_ = viper.BindPFlag("general.AlignStable", rootCmd.PersistentFlags().Lookup("align-stable"))
+
+	rootCmd.PersistentFlags().BoolP("apply-transform", "", false, "apply the column transforms also to search, filter and export")
+	_ = viper.BindPFlag("general.ApplyTransform", rootCmd.PersistentFlags().Lookup("apply-transform"))
+
 	rootCmd.PersistentFlags().BoolP("line-number", "n", false, "show line numbers")
 	_ = viper.BindPFlag("general.LineNumMode", rootCmd.PersistentFlags().Lookup("line-number"))
 
@@ -550,6 +558,15 @@ func init() {
 		return []string{",\tcomma", "|\tvertical line", "\\\\t\ttab", "│\tbox"}, cobra.ShellCompDirectiveNoFileComp
 	})
 
+	rootCmd.PersistentFlags().StringP("column-regexp", "", "", "`regexp` with named groups that split the columns")
+	_ = viper.BindPFlag("general.ColumnRegexp", rootCmd.PersistentFlags().Lookup("column-regexp"))
+
+	rootCmd.PersistentFlags().StringSliceP("column-names", "", nil, "names of the columns for data without a header line (e.g., \"name,size,count\")")
+	_ = viper.BindPFlag("general.ColumnNames", rootCmd.PersistentFlags().Lookup("column-names"))
+
+	rootCmd.PersistentFlags().StringSliceP("logfmt-keys", "", nil, "logfmt keys to display as columns (e.g., \"time,level,msg\")")
+	_ = viper.BindPFlag("general.LogfmtKeys", rootCmd.PersistentFlags().Lookup("logfmt-keys"))
+
 	rootCmd.PersistentFlags().StringP("section-delimiter", "", "", "`regexp` marking section boundaries (e.g., \"^#\")")
 	_ = viper.BindPFlag("general.SectionDelimiter", rootCmd.PersistentFlags().Lookup("section-delimiter"))
 
@@ -592,6 +609,18 @@ func init() {
 	rootCmd.PersistentFlags().BoolP("hide-other-section", "", false, "hide all sections except the current one")
 	_ = viper.BindPFlag("general.HideOtherSection", rootCmd.PersistentFlags().Lookup("hide-other-section"))
 
+	rootCmd.PersistentFlags().BoolP("diff", "", false, "diff mode: style and navigate unified diffs")
+	_ = viper.BindPFlag("general.DiffMode", rootCmd.PersistentFlags().Lookup("diff"))
+
+	rootCmd.PersistentFlags().BoolP("man", "", false, "man page mode: navigate by headings, options and references")
+	_ = viper.BindPFlag("general.ManMode", rootCmd.PersistentFlags().Lookup("man"))
+
+	rootCmd.PersistentFlags().StringP("syntax", "", "", "syntax highlighting language, or auto to select it by the file extension")
+	_ = viper.BindPFlag("general.Syntax", rootCmd.PersistentFlags().Lookup("syntax"))
+	_ = rootCmd.RegisterFlagCompletionFunc("syntax", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return append([]string{oviewer.SyntaxAuto}, oviewer.SyntaxNames()...), cobra.ShellCompDirectiveNoFileComp
+	})
+
 	rootCmd.PersistentFlags().BoolP("status-line", "", true, "show the status line at the bottom")
 	_ = viper.BindPFlag("general.StatusLine", rootCmd.PersistentFlags().Lookup("status-line"))
 
@@ -628,9 +657,24 @@ func init() {
 	rootCmd.PersistentFlags().BoolP("regexp-search", "", false, "treat search patterns as regular expressions")
 	_ = viper.BindPFlag("RegexpSearch", rootCmd.PersistentFlags().Lookup("regexp-search"))
 
+	rootCmd.PersistentFlags().BoolP("normalize-search", "", false, "search ignoring full-width/half-width and composed/decomposed differences (NFKC)")
+	_ = viper.BindPFlag("NormalizeSearch", rootCmd.PersistentFlags().Lookup("normalize-search"))
+
+	rootCmd.PersistentFlags().BoolP("fold-diacritics", "", false, "search ignoring diacritical marks (implies --normalize-search)")
+	_ = viper.BindPFlag("FoldDiacritics", rootCmd.PersistentFlags().Lookup("fold-diacritics"))
+
 	rootCmd.PersistentFlags().BoolP("incsearch", "", true, "incremental search")
 	_ = viper.BindPFlag("Incsearch", rootCmd.PersistentFlags().Lookup("incsearch"))
 
+	rootCmd.PersistentFlags().BoolP("search-background", "", false, "search in the background and keep redrawing while scanning")
+	_ = viper.BindPFlag("SearchBackground", rootCmd.PersistentFlags().Lookup("search-background"))
+
+	rootCmd.PersistentFlags().BoolP("auto-detect", "", false, "detect the input format and apply a matching view mode")
+	_ = viper.BindPFlag("AutoDetect", rootCmd.PersistentFlags().Lookup("auto-detect"))
+
+	rootCmd.PersistentFlags().BoolP("lessopen", "", false, "preprocess input files with the LESSOPEN environment variable")
+	_ = viper.BindPFlag("LessOpen", rootCmd.PersistentFlags().Lookup("lessopen"))
+
 	rootCmd.PersistentFlags().IntP("memory-limit", "", -1, "maximum chunks to keep in memory (-1 for unlimited)")
 	_ = viper.BindPFlag("MemoryLimit", rootCmd.PersistentFlags().Lookup("memory-limit"))
 
@@ -640,6 +684,12 @@ func init() {
 	rootCmd.PersistentFlags().BoolP("disable-mouse", "", false, "disable mouse support")
 	_ = viper.BindPFlag("DisableMouse", rootCmd.PersistentFlags().Lookup("disable-mouse"))
 
+	rootCmd.PersistentFlags().BoolP("disable-link-open", "", false, "disable opening links")
+	_ = viper.BindPFlag("DisableLinkOpen", rootCmd.PersistentFlags().Lookup("disable-link-open"))
+
+	rootCmd.PersistentFlags().BoolP("link-open-file", "", false, "open file URLs and file:line links")
+	_ = viper.BindPFlag("LinkOpenFile", rootCmd.PersistentFlags().Lookup("link-open-file"))
+
 	rootCmd.PersistentFlags().BoolP("disable-column-cycle", "", false, "keep column cursor from wrapping to the first column")
 	_ = viper.BindPFlag("DisableColumnCycle", rootCmd.PersistentFlags().Lookup("disable-column-cycle"))
 
@@ -649,7 +699,7 @@ func init() {
 	rootCmd.PersistentFlags().StringP("sidebar-mode", "", "", "open sidebar with this content [help|marks|documents|sections]")
 	_ = viper.BindPFlag("SidebarMode", rootCmd.PersistentFlags().Lookup("sidebar-mode"))
 	_ = rootCmd.RegisterFlagCompletionFunc("sidebar-mode", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-		return []string{"help", "marks", "documents", "sections", "styles"}, cobra.ShellCompDirectiveNoFileComp
+		return []string{"help", "marks", "documents", "sections", "styles", "stats", "record", "links"}, cobra.ShellCompDirectiveNoFileComp
 	})
 
 	rootCmd.PersistentFlags().BoolP("set-terminal-title", "", false, "update the terminal title bar with the current file name")
@@ -25,6 +25,9 @@ func (root *Root) toggleWrapMode(context.Context) {
 		m.Converter = convEscaped
 		m.ClearCache()
 	}
+	if m.Converter == convJSON {
+		m.ClearCache()
+	}
 
 	// Move cursor to correct position
 	x, err := m.optimalX(root.scr, m.columnCursor)
@@ -432,6 +435,10 @@ func (root *Root) setViewMode(ctx context.Context, modeName string) {
 	m.ClearCache()
 	root.ViewSync(ctx)
 	root.setMessageLogf("Set mode %s", modeName)
+	// The vt converter renders the document into a new document.
+	if m.Converter == convVT {
+		root.vtDocument(ctx)
+	}
 }
 
 // modeConfig returns the configuration of the specified mode.
@@ -473,8 +480,23 @@ func (root *Root) setConverter(ctx context.Context, name string) {
 	if m.Converter == name {
 		return
 	}
+	// The vt converter renders the whole document into a new document.
+	if name == convVT {
+		root.vtDocument(ctx)
+		return
+	}
+	prev := m.Converter
 	m.Converter = name
+	// The json converter expands one line into multiple rows.
+	if name == convJSON {
+		m.WrapMode = true
+	}
 	m.ClearCache()
+	// The headings of the markdown converter are the sections.
+	if name == convMarkdown || prev == convMarkdown {
+		m.sectionListDirty = true
+		root.generateSectionList()
+	}
 	root.ViewSync(ctx)
 	root.setMessagef("Set %s converter", name)
 }
@@ -489,11 +511,19 @@ func (root *Root) alignFormat(ctx context.Context) {
 }
 
 // rawFormat sets converter type to raw.
+// The markdown converter is restored when the raw converter is toggled off,
+// so that the source and the rendered markdown can be switched.
 func (root *Root) rawFormat(ctx context.Context) {
-	if root.Doc.Converter == convRaw {
+	m := root.Doc
+	if m.Converter == convRaw {
+		if m.rawFrom == convMarkdown {
+			root.setConverter(ctx, convMarkdown)
+			return
+		}
 		root.esFormat(ctx)
 		return
 	}
+	m.rawFrom = m.Converter
 	root.setConverter(ctx, convRaw)
 }
 
@@ -622,14 +652,19 @@ func (root *Root) generateSectionList() {
 	if !m.sectionListDirty {
 		return
 	}
-	if m.SectionDelimiter == "" {
+	if m.sectionKind() == sectionNone {
 		root.sendUpdateSections(nil)
 		return
 	}
-	sectionDelimiter := m.SectionDelimiter
-	sectionDelimiterReg := m.SectionDelimiterReg
 	sectionStartPosition := m.SectionStartPosition
-	searcher := NewSearcher(sectionDelimiter, sectionDelimiterReg, true, true)
+	if sectionList := m.sectionLister(); sectionList != nil {
+		root.debugMessage("generate sectionList without delimiter")
+		go func() {
+			root.sendUpdateSections(sectionList(context.Background(), sectionStartPosition))
+		}()
+		return
+	}
+	searcher := m.sectionSearcher()
 	if searcher == nil {
 		root.sendUpdateSections(nil)
 		return
@@ -746,7 +781,7 @@ func (root *Root) setHeaderColumn(input string) {
 
 // resize is a wrapper function that calls viewSync.
 func (root *Root) resize(ctx context.Context) {
-	if root.Doc.Converter == convWordWrap {
+	if root.Doc.Converter == convWordWrap || root.Doc.Converter == convJSON {
 		root.Doc.ClearCache()
 	}
 	root.ViewSync(ctx)
@@ -890,8 +925,13 @@ func (root *Root) followSection(ctx context.Context) {
 	}
 }
 
-// Cancel follow mode and follow all mode.
+// Cancel cancels the background search, follow mode and follow all mode.
 func (root *Root) Cancel(context.Context) {
+	// Cancel the search running in the background.
+	if root.searchCancelFunc != nil {
+		root.searchCancelFunc()
+		root.searchCancelFunc = nil
+	}
 	root.setFollowAll(false)
 	root.Doc.setFollowMode(false)
 }
@@ -946,7 +986,7 @@ func (root *Root) ExpandColumn(_ context.Context, cursor int) error {
 
 // toggleShrinkColumn shrinks or expands the current cursor column.
 func (root *Root) toggleShrinkColumn(_ context.Context) {
-	cursor := root.Doc.columnCursor
+	cursor := root.Doc.cursorColumn()
 	shrink, err := root.Doc.isColumnShrink(cursor)
 	if err != nil {
 		root.setMessage(err.Error())
@@ -977,7 +1017,7 @@ func (m *Document) isColumnShrink(cursor int) (bool, error) {
 // toggleRightAlign toggles the right align of the current cursor column.
 func (root *Root) toggleRightAlign(_ context.Context) {
 	m := root.Doc
-	align, err := m.toggleRightAlign(m.columnCursor)
+	align, err := m.toggleRightAlign(m.cursorColumn())
 	if err != nil {
 		root.setMessage(err.Error())
 		return
@@ -0,0 +1,115 @@
+package oviewer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// stableWidths is the column widths of align mode computed over the document.
+type stableWidths struct {
+	// key identifies the column settings the widths were computed with.
+	key string
+	// widths is the maximum width of each column.
+	widths []int
+	// scanned is the line number up to which the widths have been computed.
+	scanned int
+}
+
+// stableWidthsKey returns the key of the column settings that affect the column widths.
+func (m *Document) stableWidthsKey() string {
+	reg := ""
+	if m.ColumnRegexpReg != nil {
+		reg = m.ColumnRegexpReg.String()
+	}
+	return fmt.Sprintf("%s\x00%s\x00%t\x00%d\x00%d\x00%v", m.ColumnDelimiter, reg, m.ColumnWidth, m.TabWidth, m.SkipLines, m.columnWidths)
+}
+
+// stableScanEnd returns the line number up to which the widths are computed.
+// If AlignSample is set, only that number of lines are computed.
+func (m *Document) stableScanEnd() int {
+	end := m.BufEndNum()
+	if m.AlignSample > 0 {
+		end = min(end, m.firstLine()+m.AlignSample)
+	}
+	return end
+}
+
+// eventStableWidths represents the event that the column widths are computed.
+type eventStableWidths struct {
+	m      *Document
+	widths stableWidths
+	tcell.EventTime
+}
+
+// updateStableWidths computes the column widths of the lines not computed yet in the background.
+// The lines appended in follow mode are computed incrementally.
+func (root *Root) updateStableWidths(m *Document) {
+	key := m.stableWidthsKey()
+	if m.stableWidths.key != key {
+		m.stableWidths = stableWidths{key: key, scanned: m.SkipLines}
+	}
+	start := m.stableWidths.scanned
+	end := m.stableScanEnd()
+	if start >= end {
+		return
+	}
+	if !m.stableRunning.CompareAndSwap(false, true) {
+		return
+	}
+	widths := slices.Clone(m.stableWidths.widths)
+	settings := m.columnWidthSettings()
+	go func() {
+		defer m.stableRunning.Store(false)
+		widths, scanned := m.scanColumnWidths(context.Background(), settings, widths, start, end)
+		ev := &eventStableWidths{
+			m:      m,
+			widths: stableWidths{key: key, widths: widths, scanned: scanned},
+		}
+		ev.SetEventNow()
+		root.postEvent(ev)
+	}()
+}
+
+// scanColumnWidths returns the maximum column widths of the lines from start to end
+// and the line number up to which the widths have been computed.
+// The settings are taken from the document before starting, because it runs in the background.
+func (m *Document) scanColumnWidths(ctx context.Context, settings columnWidthSettings, widths []int, start int, end int) ([]int, int) {
+	var rightCount []int
+	scanned := start
+	err := m.eachLine(ctx, start, func(lN int, line []byte) bool {
+		if lN >= end {
+			return false
+		}
+		widths, rightCount = settings.lineColumnWidths(widths, rightCount, string(line))
+		scanned = lN + 1
+		return true
+	})
+	if err != nil {
+		log.Printf("failed to compute column widths: %v\n", err)
+	}
+	return widths, scanned
+}
+
+// setStableWidths sets the computed column widths if the column settings have not changed.
+func (m *Document) setStableWidths(widths stableWidths) {
+	if widths.key != m.stableWidthsKey() {
+		return
+	}
+	m.stableWidths = widths
+}
+
+// mergeStableWidths returns the widths widened to the column widths computed over the document.
+func (m *Document) mergeStableWidths(maxWidths []int) []int {
+	for i, width := range m.stableWidths.widths {
+		if i < len(maxWidths) {
+			maxWidths[i] = max(maxWidths[i], width)
+			continue
+		}
+		maxWidths = append(maxWidths, width)
+	}
+	return maxWidths
+}
@@ -0,0 +1,111 @@
+package oviewer
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+func TestDocument_scanColumnWidths(t *testing.T) {
+	tests := []struct {
+		name        string
+		sample      int
+		wantWidths  []int
+		wantScanned int
+	}{
+		{
+			name:        "whole document",
+			sample:      0,
+			wantWidths:  []int{4, 4, 5},
+			wantScanned: 5,
+		},
+		{
+			name:        "sample",
+			sample:      2,
+			wantWidths:  []int{4, 4, 5},
+			wantScanned: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := docFileReadHelper(t, filepath.Join(testdata, "sort.csv"))
+			m.WaitEOF()
+			m.ColumnMode = true
+			m.ColumnDelimiter = ","
+			m.AlignSample = tt.sample
+			m.regexpCompile()
+			widths, scanned := m.scanColumnWidths(context.Background(), m.columnWidthSettings(), nil, 0, m.stableScanEnd())
+			if !reflect.DeepEqual(widths, tt.wantWidths) {
+				t.Errorf("scanColumnWidths() widths = %v, want %v", widths, tt.wantWidths)
+			}
+			if scanned != tt.wantScanned {
+				t.Errorf("scanColumnWidths() scanned = %v, want %v", scanned, tt.wantScanned)
+			}
+		})
+	}
+}
+
+func TestDocument_mergeStableWidths(t *testing.T) {
+	m := docHelper(t, "")
+	m.stableWidths = stableWidths{widths: []int{3, 1, 7}}
+	if got, want := m.mergeStableWidths([]int{2, 5}), []int{3, 5, 7}; !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeStableWidths() = %v, want %v", got, want)
+	}
+}
+
+func TestDocument_setStableWidths(t *testing.T) {
+	m := docHelper(t, "")
+	m.ColumnDelimiter = ","
+	widths := stableWidths{key: m.stableWidthsKey(), widths: []int{1, 2}, scanned: 2}
+	m.ColumnDelimiter = "|"
+	m.setStableWidths(widths)
+	if m.stableWidths.widths != nil {
+		t.Errorf("setStableWidths() set the widths of the other settings")
+	}
+	m.ColumnDelimiter = ","
+	m.setStableWidths(widths)
+	if !reflect.DeepEqual(m.stableWidths, widths) {
+		t.Errorf("setStableWidths() = %v, want %v", m.stableWidths, widths)
+	}
+}
+
+func TestRoot_updateStableWidths(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "sort.csv"))
+	root.Doc.WaitEOF()
+	root.prepareScreen()
+	root.screenState.Store(ScreenStateReady)
+	m := root.Doc
+	m.ColumnMode = true
+	m.ColumnDelimiter = ","
+	m.AlignStable = true
+	m.regexpCompile()
+	root.updateStableWidths(m)
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case ev := <-root.Screen.EventQ():
+			sw, ok := ev.(*eventStableWidths)
+			if !ok {
+				continue
+			}
+			m.setStableWidths(sw.widths)
+			if want := []int{4, 4, 5}; !reflect.DeepEqual(m.stableWidths.widths, want) {
+				t.Errorf("updateStableWidths() widths = %v, want %v", m.stableWidths.widths, want)
+			}
+			if m.stableWidths.scanned != m.BufEndNum() {
+				t.Errorf("updateStableWidths() scanned = %v, want %v", m.stableWidths.scanned, m.BufEndNum())
+			}
+			return
+		case <-timeout:
+			t.Fatal("timeout waiting for eventStableWidths")
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package oviewer
+
+import (
+	"context"
+	"io"
+	"log"
+	"strings"
+)
+
+// columnDocumentDelimiter is the column delimiter of the column document.
+const columnDocumentDelimiter = "\t"
+
+// columnExtractor returns the column values of a line.
+// It returns nil if the line cannot be split into columns.
+type columnExtractor func(line []byte) []string
+
+// columnValueReplacer replaces the characters that break the columns or rows.
+var columnValueReplacer = strings.NewReplacer("\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// columnHeader returns the column names of the column document.
+// It is called before the lines are extracted.
+type columnHeader func(ctx context.Context) []string
+
+// columnDocument creates a new document in which the columns extracted
+// from each line of the current document are separated by tabs.
+// The header is written as the first line and pinned as a header.
+func (root *Root) columnDocument(ctx context.Context, caption string, header columnHeader, extract columnExtractor) {
+	m := root.Doc
+	render, w, err := newColumnDocument(m, caption)
+	if err != nil {
+		log.Printf("failed to extract columns: %v\n", err)
+		return
+	}
+	root.insertDocument(ctx, root.CurrentDoc, render)
+
+	go func() {
+		defer closeFile(w)
+		m.WaitEOFWithTimeout(root.Config.ReadWaitTime)
+		names := header(ctx)
+		writeLine(w, []byte(joinColumns(names)))
+		m.columnWriter(ctx, w, render, len(names), extract)
+	}()
+}
+
+// newColumnDocument returns a new document whose columns are separated by tabs,
+// and the writer to write the lines to.
+// The first line of the document is pinned as a header.
+func newColumnDocument(m *Document, caption string) (*Document, io.WriteCloser, error) {
+	r, w := io.Pipe()
+	render, err := renderDoc(m, r)
+	if err != nil {
+		closeFile(r)
+		closeFile(w)
+		return nil, nil, err
+	}
+	render.documentType = DocColumns
+	render.RunTimeSettings = m.RunTimeSettings
+	render.Caption = caption
+	render.SkipLines = 0
+	render.Header = 1
+	render.ColumnMode = true
+	render.ColumnDelimiter = columnDocumentDelimiter
+	render.ColumnRegexp = ""
+	render.Converter = convAlign
+	render.regexpCompile()
+	return render, w, nil
+}
+
+// columnWriter writes the extracted columns of each line to w.
+func (m *Document) columnWriter(ctx context.Context, w io.Writer, render *Document, num int, extract columnExtractor) {
+	renderLN := render.firstLine()
+	err := m.eachLine(ctx, m.firstLine(), func(lN int, line []byte) bool {
+		values := extract(line)
+		if values == nil {
+			values = make([]string, num)
+		}
+		render.lineNumMap.Store(renderLN, lN)
+		writeLine(w, []byte(joinColumns(values)))
+		renderLN++
+		return true
+	})
+	if err != nil {
+		log.Printf("failed to extract columns: %v\n", err)
+	}
+}
+
+// joinColumns joins the values with the column delimiter.
+func joinColumns(values []string) string {
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		escaped[i] = columnValueReplacer.Replace(v)
+	}
+	return strings.Join(escaped, columnDocumentDelimiter)
+}
+
+// parseColumnNames parses the comma-separated column names.
+func parseColumnNames(input string) []string {
+	var names []string
+	for name := range strings.SplitSeq(input, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// toggleColumnDocument returns to the parent document if the current document is a column document.
+// It returns false if the current document is not a column document.
+func (root *Root) toggleColumnDocument(ctx context.Context) bool {
+	fromDoc := root.Doc
+	if fromDoc.documentType != DocColumns || fromDoc.parent == nil {
+		return false
+	}
+	toNum := root.CurrentDoc
+	root.mu.RLock()
+	for n, doc := range root.DocList {
+		if doc == fromDoc.parent {
+			toNum = n
+		}
+	}
+	root.mu.RUnlock()
+	root.setDocumentNum(ctx, toNum)
+	root.linkLineNum(fromDoc, root.Doc)
+	return true
+}
@@ -0,0 +1,87 @@
+package oviewer
+
+import (
+	"strconv"
+	"strings"
+)
+
+// showColumnNames returns true if the column names given by ColumnNames
+// are displayed as a synthetic header.
+// The names of the column regexp take precedence over ColumnNames.
+func (m *Document) showColumnNames() bool {
+	return m.ColumnMode && len(m.ColumnNames) > 0 && m.ColumnRegexpReg == nil
+}
+
+// columnNamesLine returns the column names joined so that
+// the line is split into the same columns as the body.
+func (m *Document) columnNamesLine() string {
+	if m.ColumnWidth {
+		var sb strings.Builder
+		for i, name := range m.ColumnNames {
+			sb.WriteString(name)
+			if i >= len(m.columnWidths) || i == len(m.ColumnNames)-1 {
+				continue
+			}
+			// The column ends with a space at the column width position.
+			pad := max(m.columnWidths[i]+1-stringWidth(sb.String()), 1)
+			sb.WriteString(strings.Repeat(" ", pad))
+		}
+		return sb.String()
+	}
+	delimiter := m.ColumnDelimiter
+	if m.ColumnDelimiterReg != nil {
+		delimiter = " "
+	}
+	return strings.Join(m.ColumnNames, delimiter)
+}
+
+// columnNamesLineC returns the contents of the synthetic header of the column names.
+func (root *Root) columnNamesLineC() LineC {
+	m := root.Doc
+	lc, eolStyle := parseLine(m.converterType(m.Converter), m.columnNamesLine(), m.TabWidth)
+	str, pos := ContentsToStr(lc)
+	lineC := LineC{
+		lc:       lc,
+		str:      str,
+		pos:      pos,
+		valid:    true,
+		eolStyle: eolStyle,
+	}
+	lineC = m.columnRanges(lineC)
+	RangeStyle(lineC.lc, 0, len(lineC.lc), m.Style.Body)
+	root.styleContent(lineC)
+	return lineC
+}
+
+// drawColumnNames draws the column names as a synthetic header below the ruler.
+func (root *Root) drawColumnNames() {
+	if root.scr.columnNamesHeight == 0 {
+		return
+	}
+	m := root.Doc
+	y := root.scr.rulerHeight
+	root.blankLineNumber(y)
+	lineC := root.columnNamesLineC()
+	root.drawNoWrapLine(y, m.scrollX, -1, lineC)
+	root.drawVerticalHeader(y, 0, lineC)
+	root.applyStyleToLine(y, m.Style.Header)
+}
+
+// columnStatus returns the column under the cursor for the status line.
+// It returns the column number and the name, such as "[1:size]".
+// The original value of the current row follows if the column is transformed, such as "[1:size=1536]".
+func (root *Root) columnStatus() string {
+	m := root.Doc
+	if !m.ColumnMode {
+		return ""
+	}
+	column := m.cursorColumn()
+	label := strconv.Itoa(column)
+	if name := m.columnName(column); name != "" {
+		label += ":" + name
+	}
+	if original, ok := root.originalValue(column); ok {
+		label += "=" + original
+	}
+	return "[" + label + "]"
+}
@@ -0,0 +1,142 @@
+package oviewer
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+func TestDocument_columnNamesLine(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		delimiter    string
+		columnWidth  bool
+		columnWidths []int
+		names        []string
+		want         string
+	}{
+		{
+			name:      "comma",
+			delimiter: ",",
+			names:     []string{"name", "size", "count"},
+			want:      "name,size,count",
+		},
+		{
+			name:      "regexp delimiter",
+			delimiter: `/\s+/`,
+			names:     []string{"name", "size"},
+			want:      "name size",
+		},
+		{
+			name:         "column width",
+			columnWidth:  true,
+			columnWidths: []int{5, 10},
+			names:        []string{"pid", "tty", "command"},
+			want:         "pid   tty  command",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := docHelper(t, "")
+			m.setDelimiter(tt.delimiter)
+			m.ColumnWidth = tt.columnWidth
+			m.columnWidths = tt.columnWidths
+			m.ColumnNames = tt.names
+			if got := m.columnNamesLine(); got != tt.want {
+				t.Errorf("columnNamesLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocument_headerColumnNames_columnNames(t *testing.T) {
+	t.Parallel()
+	m := docHelper(t, "a,1\nb,2\n")
+	m.ColumnMode = true
+	m.ColumnDelimiter = ","
+	m.ColumnNames = []string{"name", "value"}
+	if got := m.headerColumnNames(); !slices.Equal(got, []string{"name", "value"}) {
+		t.Errorf("headerColumnNames() = %v, want [name value]", got)
+	}
+	if got := m.columnName(1); got != "value" {
+		t.Errorf("columnName(1) = %q, want %q", got, "value")
+	}
+	if !m.showColumnNames() {
+		t.Error("showColumnNames() = false, want true")
+	}
+	// The names of the column regexp take precedence.
+	m.setColumnRegexp(`(?P<key>\w+),(?P<num>\d+)`)
+	if got := m.columnName(1); got != "num" {
+		t.Errorf("columnName(1) = %q, want %q", got, "num")
+	}
+	if m.showColumnNames() {
+		t.Error("showColumnNames() = true, want false")
+	}
+}
+
+func TestRoot_drawColumnNames(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "sort.csv"))
+	m := root.Doc
+	m.ColumnMode = true
+	m.ColumnDelimiter = ","
+	m.SkipLines = 1
+	m.Converter = convAlign
+	m.ColumnNames = []string{"item", "bytes", "n"}
+	m.regexpCompile()
+	ctx := context.Background()
+	root.prepareScreen()
+	root.draw(ctx)
+	root.draw(ctx)
+
+	if m.headerHeight != 1 {
+		t.Errorf("headerHeight = %d, want 1", m.headerHeight)
+	}
+	tests := []struct {
+		y    int
+		want string
+	}{
+		{y: 0, want: "item,bytes,n"},
+		{y: 1, want: "b   ,1.5K ,10"},
+	}
+	for _, tt := range tests {
+		if got := strings.TrimRight(getContents(t, root, tt.y, 20), " "); got != tt.want {
+			t.Errorf("line %d = %q, want %q", tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestRoot_columnStatus(t *testing.T) {
+	root := rootFileReadHelper(t, filepath.Join(testdata, "sort.csv"))
+	m := root.Doc
+	if got := root.columnStatus(); got != "" {
+		t.Errorf("columnStatus() = %q, want empty", got)
+	}
+	m.ColumnMode = true
+	m.ColumnDelimiter = ","
+	m.Header = 1
+	m.regexpCompile()
+	tests := []struct {
+		cursor int
+		want   string
+	}{
+		{cursor: 0, want: "[0:name]"},
+		{cursor: 1, want: "[1:size]"},
+		{cursor: 5, want: "[5]"},
+	}
+	for _, tt := range tests {
+		m.columnCursor = tt.cursor
+		if got := root.columnStatus(); got != tt.want {
+			t.Errorf("columnStatus() = %q, want %q", got, tt.want)
+		}
+	}
+}
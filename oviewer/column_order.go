@@ -0,0 +1,171 @@
+package oviewer
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strconv"
+)
+
+// cursorColumn returns the original column number of the column under the cursor.
+// In align mode, the cursor points to the displayed column, which may be reordered.
+func (m *Document) cursorColumn() int {
+	if m.Converter != convAlign {
+		return m.columnCursor
+	}
+	return m.alignConv.columnNum(m.columnCursor)
+}
+
+// hideColumn hides the column under the cursor (align mode only).
+func (root *Root) hideColumn(context.Context) {
+	m := root.Doc
+	if err := m.isValidColumn(m.columnCursor); err != nil {
+		root.setMessage(err.Error())
+		return
+	}
+	display := m.alignConv.displayColumns(len(m.alignConv.columnAttrs))
+	if len(display) <= 1 {
+		root.setMessage("cannot hide all columns")
+		return
+	}
+	column := m.cursorColumn()
+	m.alignConv.columnAttrs[column].hidden = true
+	m.columnCursor = min(m.columnCursor, len(display)-2)
+	m.ClearCache()
+	root.setMessagef("hide column %s", m.columnLabel(column))
+}
+
+// unhideColumns shows all hidden columns.
+func (root *Root) unhideColumns(context.Context) {
+	m := root.Doc
+	num := 0
+	for i := range m.alignConv.columnAttrs {
+		if m.alignConv.columnAttrs[i].hidden {
+			m.alignConv.columnAttrs[i].hidden = false
+			num++
+		}
+	}
+	if num == 0 {
+		root.setMessage("no hidden columns")
+		return
+	}
+	m.ClearCache()
+	root.setMessagef("show %d columns", num)
+}
+
+// moveColumnOrderLeft moves the column under the cursor to the left (align mode only).
+func (root *Root) moveColumnOrderLeft(context.Context) {
+	root.moveColumnOrder(-1)
+}
+
+// moveColumnOrderRight moves the column under the cursor to the right (align mode only).
+func (root *Root) moveColumnOrderRight(context.Context) {
+	root.moveColumnOrder(1)
+}
+
+// moveColumnOrder moves the column under the cursor by n in the display order.
+// The cursor follows the moved column.
+func (root *Root) moveColumnOrder(n int) {
+	m := root.Doc
+	if err := m.isValidColumn(m.columnCursor); err != nil {
+		root.setMessage(err.Error())
+		return
+	}
+	cursor, err := m.alignConv.moveColumn(m.columnCursor, n)
+	if err != nil {
+		root.setMessage(err.Error())
+		return
+	}
+	m.columnCursor = cursor
+	m.ClearCache()
+}
+
+// applyColumnSettings applies ColumnHide, ColumnOrder and ColumnTransform to the align converter.
+// They are applied again only when the settings change,
+// so that the changes made by the actions are kept.
+func (m *Document) applyColumnSettings() {
+	a := m.alignConv
+	if slices.Equal(a.appliedHide, m.ColumnHide) && slices.Equal(a.appliedOrder, m.ColumnOrder) && slices.Equal(a.appliedTrans, m.ColumnTransform) {
+		return
+	}
+	a.appliedHide = m.ColumnHide
+	a.appliedOrder = m.ColumnOrder
+	a.appliedTrans = m.ColumnTransform
+
+	names := m.headerColumnNames()
+	for i := range a.columnAttrs {
+		a.columnAttrs[i].hidden = false
+	}
+	for _, column := range m.ColumnHide {
+		if n, ok := columnIndex(column, names); ok && n < len(a.columnAttrs) {
+			a.columnAttrs[n].hidden = true
+		}
+	}
+	for i := range a.columnAttrs {
+		a.columnAttrs[i].transform = ""
+	}
+	for _, str := range m.ColumnTransform {
+		n, name, err := parseColumnTransform(str, names)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		if n < len(a.columnAttrs) {
+			a.columnAttrs[n].transform = name
+		}
+	}
+	a.columnOrder = nil
+	for _, column := range m.ColumnOrder {
+		if n, ok := columnIndex(column, names); ok {
+			a.columnOrder = append(a.columnOrder, n)
+		}
+	}
+	m.ClearCache()
+}
+
+// headerColumnNames returns the names of the columns.
+// The group names are used if ColumnRegexp is set, then ColumnNames,
+// otherwise the values of the first header line.
+func (m *Document) headerColumnNames() []string {
+	if m.ColumnRegexpReg != nil {
+		return m.ColumnRegexpReg.SubexpNames()[1:]
+	}
+	if len(m.ColumnNames) > 0 {
+		return slices.Clone(m.ColumnNames)
+	}
+	if m.Header == 0 {
+		return nil
+	}
+	line, err := m.Line(m.SkipLines)
+	if err != nil {
+		return nil
+	}
+	values := m.columnValues(string(line))
+	for i, v := range values {
+		values[i] = trimColumnValue(v)
+	}
+	return values
+}
+
+// columnIndex returns the column number of the column specified by the index or the name.
+func columnIndex(column string, names []string) (int, bool) {
+	if n, err := strconv.Atoi(column); err == nil {
+		return n, n >= 0
+	}
+	n := slices.Index(names, column)
+	return n, n >= 0
+}
+
+// displayColumnValues returns the values in the display order of the align mode.
+// Hidden columns are removed. It returns values as they are if the columns are not reordered.
+func (m *Document) displayColumnValues(values []string) []string {
+	if m.Converter != convAlign || !m.alignConv.isReordered() {
+		return values
+	}
+	display := m.alignConv.displayColumns(len(values))
+	ordered := make([]string, 0, len(display))
+	for _, n := range display {
+		ordered = append(ordered, values[n])
+	}
+	return ordered
+}
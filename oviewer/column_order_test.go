@@ -0,0 +1,128 @@
+package oviewer
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+func Test_columnIndex(t *testing.T) {
+	t.Parallel()
+	names := []string{"id", "name", "email"}
+	tests := []struct {
+		column string
+		want   int
+		wantOK bool
+	}{
+		{column: "2", want: 2, wantOK: true},
+		{column: "email", want: 2, wantOK: true},
+		{column: "-1", want: -1, wantOK: false},
+		{column: "missing", want: -1, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.column, func(t *testing.T) {
+			t.Parallel()
+			got, ok := columnIndex(tt.column, names)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("columnIndex() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDocument_applyColumnSettings(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	tests := []struct {
+		name       string
+		hide       []string
+		order      []string
+		wantValues []string
+		cursor     int
+	}{
+		{
+			name:       "hide by name and index",
+			hide:       []string{"email", "0"},
+			wantValues: []string{"first_name", "last_name", "gender", "ip_address", "animal", "app"},
+			cursor:     0,
+		},
+		{
+			name:       "order by name",
+			order:      []string{"app", "email"},
+			wantValues: []string{"app", "email", "id", "first_name", "last_name", "gender", "ip_address", "animal"},
+			cursor:     3,
+		},
+		{
+			name:       "order and hide",
+			hide:       []string{"id"},
+			order:      []string{"last_name", "first_name"},
+			wantValues: []string{"last_name", "first_name", "email", "gender", "ip_address", "animal", "app"},
+			cursor:     1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := rootFileReadHelper(t, filepath.Join(testdata, "MOCK_DATA.csv"))
+			root.Doc.WaitEOF()
+			m := root.Doc
+			m.ColumnMode = true
+			m.ColumnDelimiter = ","
+			m.Header = 1
+			m.Converter = convAlign
+			m.ColumnHide = tt.hide
+			m.ColumnOrder = tt.order
+			m.regexpCompile()
+			root.prepareScreen()
+			root.prepareDraw(context.Background())
+
+			line, err := m.Line(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := m.displayColumnValues(m.columnValues(string(line))); !reflect.DeepEqual(got, tt.wantValues) {
+				t.Errorf("displayColumnValues() = %v, want %v", got, tt.wantValues)
+			}
+			// The cursor points to the displayed column.
+			m.columnCursor = tt.cursor
+			if got := m.columnValue(string(line), m.cursorColumn()); got != "first_name" {
+				t.Errorf("cursorColumn() value = %v, want first_name", got)
+			}
+		})
+	}
+}
+
+func TestRoot_hideColumn(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "MOCK_DATA.csv"))
+	root.Doc.WaitEOF()
+	m := root.Doc
+	m.ColumnMode = true
+	m.ColumnDelimiter = ","
+	m.Converter = convAlign
+	m.regexpCompile()
+	ctx := context.Background()
+	root.prepareScreen()
+	root.prepareDraw(ctx)
+
+	m.columnCursor = 1
+	root.hideColumn(ctx)
+	if !m.alignConv.isHidden(1) {
+		t.Errorf("hideColumn() column 1 is not hidden")
+	}
+	root.moveColumnOrderRight(ctx)
+	if m.columnCursor != 2 || m.cursorColumn() != 2 {
+		t.Errorf("moveColumnOrderRight() cursor = %v, column = %v, want 2, 2", m.columnCursor, m.cursorColumn())
+	}
+	root.unhideColumns(ctx)
+	if m.alignConv.isHidden(1) {
+		t.Errorf("unhideColumns() column 1 is hidden")
+	}
+}
@@ -0,0 +1,202 @@
+package oviewer
+
+import (
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// columnSearchPrefix is the prefix of the search scoped to a column, such as "@status:404".
+const columnSearchPrefix = "@"
+
+// columnRegexpCompile compiles the regular expression that splits the columns.
+// It returns nil if the regular expression is empty, invalid or has no groups.
+func columnRegexpCompile(reg string) *regexp.Regexp {
+	if reg == "" {
+		return nil
+	}
+	re, err := regexp.Compile(reg)
+	if err != nil {
+		log.Printf("column regexp: %v", err)
+		return nil
+	}
+	if re.NumSubexp() == 0 {
+		log.Printf("column regexp: no groups in %s", reg)
+		return nil
+	}
+	return re
+}
+
+// columnDelimiterIndex returns the start of the first column
+// and the positions of the delimiters that split the columns.
+// If columnReg is set, it is used instead of the delimiter.
+func columnDelimiterIndex(str string, delimiter string, delimiterReg *regexp.Regexp, columnReg *regexp.Regexp) (int, [][]int) {
+	if columnReg != nil {
+		return regexpColumnIndex(str, columnReg)
+	}
+	return 0, allIndex(str, delimiter, delimiterReg)
+}
+
+// regexpColumnIndex returns the start of the first group
+// and the positions of the text between the groups of columnReg.
+// The text between the groups is treated as the delimiter, so each group becomes a column.
+// The text before the first group is not part of any column,
+// and the text after the last group is treated as the delimiter.
+// It returns nil if the line does not match.
+func regexpColumnIndex(str string, columnReg *regexp.Regexp) (int, [][]int) {
+	match := columnReg.FindStringSubmatchIndex(str)
+	if match == nil {
+		return 0, nil
+	}
+	num := columnReg.NumSubexp()
+	indexes := make([][]int, 0, num)
+	first, end := 0, -1
+	for i := 1; i <= num; i++ {
+		start, next := match[i*2], match[i*2+1]
+		// An unmatched group is an empty column.
+		if start < 0 {
+			start, next = max(end, 0), max(end, 0)
+		}
+		// Nested groups do not overlap the previous column.
+		start, next = max(start, end), max(next, end)
+		if end >= 0 {
+			indexes = append(indexes, []int{end, start})
+		} else {
+			first = start
+		}
+		end = next
+	}
+	indexes = append(indexes, []int{end, len(str)})
+	return first, indexes
+}
+
+// columnName returns the name of the column from the column regexp,
+// ColumnNames or the header line. It returns an empty string if the column has no name.
+func (m *Document) columnName(n int) string {
+	names := m.headerColumnNames()
+	if n < 0 || n >= len(names) {
+		return ""
+	}
+	return names[n]
+}
+
+// columnLabel returns the name of the column, or the column number if it has no name.
+func (m *Document) columnLabel(column int) string {
+	if name := m.columnName(column); name != "" {
+		return name
+	}
+	return strconv.Itoa(column)
+}
+
+// columnNameMessage displays the name of the column under the cursor.
+// If the column is transformed, the original value of the current row is also displayed.
+func (root *Root) columnNameMessage() {
+	column := root.Doc.cursorColumn()
+	if original, ok := root.originalValue(column); ok {
+		root.setMessagef("column %s: %s", root.Doc.columnLabel(column), original)
+		return
+	}
+	name := root.Doc.columnName(column)
+	if name == "" {
+		return
+	}
+	root.setMessagef("column %d: %s", column, name)
+}
+
+// parseColumnSearch returns the column and the word of the search scoped to a column.
+// The column is specified by the name or the number, such as "@status:404".
+// It returns false if the word is not scoped to a column or the column does not exist.
+func parseColumnSearch(word string, names []string) (int, string, bool) {
+	if !strings.HasPrefix(word, columnSearchPrefix) {
+		return 0, "", false
+	}
+	column, str, ok := strings.Cut(word[len(columnSearchPrefix):], ":")
+	if !ok || str == "" {
+		return 0, "", false
+	}
+	n, ok := columnIndex(column, names)
+	if !ok {
+		return 0, "", false
+	}
+	return n, str, true
+}
+
+// columnSearcher is a Searcher that matches the word only in the column.
+type columnSearcher struct {
+	Searcher
+	splitter columnSplitter
+	column   int
+	name     string
+	// transformer converts the value of the column if ApplyTransform is enabled.
+	transformer *columnTransformer
+}
+
+// columnSearcher returns the Searcher scoped to the column if the word is such as "@status:404".
+// It returns nil if the word is not scoped to a column in the column mode.
+func (root *Root) columnSearcher(word string, caseSensitive bool) Searcher {
+	m := root.Doc
+	if m == nil || !m.ColumnMode {
+		return nil
+	}
+	column, str, ok := parseColumnSearch(word, m.headerColumnNames())
+	if !ok {
+		return nil
+	}
+	s := columnSearcher{
+		Searcher: root.wordSearcher(str, caseSensitive),
+		splitter: m.columnSplitter(),
+		column:   column,
+		name:     m.columnLabel(column),
+	}
+	if t, ok := m.columnTransformer(); ok {
+		s.transformer = &t
+	}
+	return s
+}
+
+// columnValue returns the value of the column to be matched.
+func (s columnSearcher) columnValue(value string) string {
+	if s.transformer != nil {
+		return s.transformer.value(s.column, value)
+	}
+	return value
+}
+
+// Match returns true if the column of the line matches.
+func (s columnSearcher) Match(target []byte) bool {
+	return s.MatchString(string(target))
+}
+
+// MatchString returns true if the column of the line matches.
+func (s columnSearcher) MatchString(target string) bool {
+	return s.Searcher.MatchString(s.columnValue(s.splitter.value(target, s.column)))
+}
+
+// FindAll returns the positions of the matches in the column.
+// The whole column is returned if the value of the column is transformed.
+func (s columnSearcher) FindAll(target string) [][]int {
+	str, ranges := s.splitter.ranges(target)
+	if s.column < 0 || s.column >= len(ranges) {
+		return nil
+	}
+	r := ranges[s.column]
+	value := str[r[0]:r[1]]
+	if converted := s.columnValue(value); converted != value {
+		if s.Searcher.MatchString(converted) {
+			return [][]int{{r[0], r[1]}}
+		}
+		return nil
+	}
+	indexes := s.Searcher.FindAll(value)
+	for _, idx := range indexes {
+		idx[0] += r[0]
+		idx[1] += r[0]
+	}
+	return indexes
+}
+
+// String returns the column and the word.
+func (s columnSearcher) String() string {
+	return columnSearchPrefix + s.name + ":" + s.Searcher.String()
+}
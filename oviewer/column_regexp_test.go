@@ -0,0 +1,220 @@
+package oviewer
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func Test_columnRegexpCompile(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		reg     string
+		wantNil bool
+	}{
+		{name: "empty", reg: "", wantNil: true},
+		{name: "invalid", reg: "(?P<a>", wantNil: true},
+		{name: "no groups", reg: `\d+`, wantNil: true},
+		{name: "named groups", reg: `(?P<a>\d+) (?P<b>\w+)`, wantNil: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := columnRegexpCompile(tt.reg); (got == nil) != tt.wantNil {
+				t.Errorf("columnRegexpCompile() = %v, wantNil %v", got, tt.wantNil)
+			}
+		})
+	}
+}
+
+func Test_regexpColumnIndex(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		reg       string
+		str       string
+		wantFirst int
+		want      [][]int
+	}{
+		{
+			name: "groups",
+			reg:  `^(?P<host>\S+) - (?P<code>\d+)`,
+			str:  "host - 200",
+			want: [][]int{{4, 7}, {10, 10}},
+		},
+		{
+			name: "suffix",
+			reg:  `^(?P<a>\w+) \[(?P<b>\w+)\]`,
+			str:  "x [y] rest",
+			want: [][]int{{1, 3}, {4, 10}},
+		},
+		{
+			name: "single group",
+			reg:  `(?P<a>\w+)`,
+			str:  "abc",
+			want: [][]int{{3, 3}},
+		},
+		{
+			name: "optional group",
+			reg:  `^(?P<a>\w+)(?: (?P<b>\d+))? (?P<c>\w+)$`,
+			str:  "x y",
+			want: [][]int{{1, 1}, {1, 2}, {3, 3}},
+		},
+		{
+			name:      "prefix",
+			reg:       `^\[(?P<level>\w+)\] (?P<msg>.*)$`,
+			str:       "[INFO] started",
+			wantFirst: 1,
+			want:      [][]int{{5, 7}, {14, 14}},
+		},
+		{
+			name: "not match",
+			reg:  `^(?P<a>\d+)$`,
+			str:  "abc",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			first, got := regexpColumnIndex(tt.str, regexp.MustCompile(tt.reg))
+			if first != tt.wantFirst || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("regexpColumnIndex() = %v, %v, want %v, %v", first, got, tt.wantFirst, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocument_columnRegexpRange(t *testing.T) {
+	t.Parallel()
+	m := docHelper(t, "")
+	m.setColumnRegexp(`^(?P<host>\S+) \S+ \[(?P<time>[^]]+)\] (?P<status>\d+)`)
+	tests := []struct {
+		name string
+		str  string
+		want []columnRange
+	}{
+		{
+			name: "match",
+			str:  "127.0.0.1 - [10/Oct/2000] 200",
+			want: []columnRange{{start: 0, end: 9}, {start: 13, end: 24}, {start: 26, end: 29}},
+		},
+		{
+			name: "not match",
+			str:  "error line",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			lc := StrToContents(tt.str, 8)
+			str, pos := ContentsToStr(lc)
+			lineC := LineC{lc: lc, str: str, pos: pos}
+			if got := m.columnDelimiterRange(lineC); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("columnDelimiterRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocument_columnRegexpPrefix(t *testing.T) {
+	t.Parallel()
+	m := docHelper(t, "")
+	m.ColumnMode = true
+	m.setColumnRegexp(`^\[(?P<level>\w+)\] (?P<msg>.*)$`)
+	str := "[INFO] started"
+	// The text before the first group is not part of the first column.
+	if got := m.columnValue(str, 0); got != "INFO" {
+		t.Errorf("columnValue(0) = %q, want %q", got, "INFO")
+	}
+	if got := m.columnValue(str, 1); got != "started" {
+		t.Errorf("columnValue(1) = %q, want %q", got, "started")
+	}
+	lc := StrToContents(str, 8)
+	lstr, pos := ContentsToStr(lc)
+	lineC := LineC{lc: lc, str: lstr, pos: pos}
+	want := []columnRange{{start: 1, end: 5}, {start: 7, end: 14}}
+	if got := m.columnDelimiterRange(lineC); !reflect.DeepEqual(got, want) {
+		t.Errorf("columnDelimiterRange() = %v, want %v", got, want)
+	}
+}
+
+func TestDocument_columnName(t *testing.T) {
+	t.Parallel()
+	m := docHelper(t, "")
+	if got := m.columnName(0); got != "" {
+		t.Errorf("columnName() = %v, want empty", got)
+	}
+	m.setColumnRegexp(`(?P<host>\S+) (\S+) (?P<status>\d+)`)
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{n: 0, want: "host"},
+		{n: 1, want: ""},
+		{n: 2, want: "status"},
+		{n: 3, want: ""},
+		{n: -1, want: ""},
+	}
+	for _, tt := range tests {
+		if got := m.columnName(tt.n); got != tt.want {
+			t.Errorf("columnName(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func Test_parseColumnSearch(t *testing.T) {
+	t.Parallel()
+	names := []string{"host", "time", "status"}
+	tests := []struct {
+		word       string
+		wantColumn int
+		wantStr    string
+		wantOK     bool
+	}{
+		{word: "@status:404", wantColumn: 2, wantStr: "404", wantOK: true},
+		{word: "@0:127", wantColumn: 0, wantStr: "127", wantOK: true},
+		{word: "@status:a:b", wantColumn: 2, wantStr: "a:b", wantOK: true},
+		{word: "status:404", wantOK: false},
+		{word: "@status:", wantOK: false},
+		{word: "@size:1", wantOK: false},
+		{word: "@mail", wantOK: false},
+	}
+	for _, tt := range tests {
+		column, str, ok := parseColumnSearch(tt.word, names)
+		if ok != tt.wantOK || (ok && (column != tt.wantColumn || str != tt.wantStr)) {
+			t.Errorf("parseColumnSearch(%q) = %d, %q, %v, want %d, %q, %v", tt.word, column, str, ok, tt.wantColumn, tt.wantStr, tt.wantOK)
+		}
+	}
+}
+
+func TestRoot_columnSearcher(t *testing.T) {
+	root := rootHelper(t)
+	m := root.Doc
+	m.ColumnMode = true
+	m.setColumnRegexp(`^(?P<host>\S+) \S+ \[(?P<time>[^]]+)\] (?P<status>\d+)`)
+	searcher := root.createSearcher("@status:200", false)
+	if got := searcher.String(); got != "@status:200" {
+		t.Errorf("String() = %q, want %q", got, "@status:200")
+	}
+	line := "10.0.0.200 - [10/Oct/2000] 200"
+	if !searcher.MatchString(line) {
+		t.Errorf("MatchString(%q) = false, want true", line)
+	}
+	if got := searcher.FindAll(line); !reflect.DeepEqual(got, [][]int{{27, 30}}) {
+		t.Errorf("FindAll() = %v, want %v", got, [][]int{{27, 30}})
+	}
+	if searcher.MatchString("10.0.0.1 - [10/Oct/2000] 404 200") {
+		t.Error("MatchString() matched outside the column")
+	}
+	// The word is searched in the whole line if the column does not exist.
+	if searcher := root.createSearcher("@size:200", false); !searcher.MatchString("@size:200") {
+		t.Error("createSearcher() did not search the word for an unknown column")
+	}
+	m.ColumnMode = false
+	if _, ok := root.createSearcher("@status:200", false).(columnSearcher); ok {
+		t.Error("createSearcher() returned columnSearcher without the column mode")
+	}
+}
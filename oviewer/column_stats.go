@@ -0,0 +1,304 @@
+package oviewer
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// columnStatsTop is the number of the most frequent values in the column statistics.
+const columnStatsTop = 10
+
+// valueCount is a value and the number of times it appears.
+type valueCount struct {
+	value string
+	count int
+}
+
+// columnStats is the statistics of a column.
+type columnStats struct {
+	doc    *Document
+	column int
+	name   string
+	// end is the line number up to which the statistics are computed.
+	end int
+	// rows is the number of lines excluding the header.
+	rows int
+	// count is the number of non-empty values.
+	count    int
+	distinct int
+	// numeric is true if all non-empty values are numbers.
+	numeric bool
+	min     string
+	max     string
+	sum     float64
+	top     []valueCount
+}
+
+// mean returns the mean of the numeric values.
+func (s *columnStats) mean() float64 {
+	if s.count == 0 {
+		return 0
+	}
+	return s.sum / float64(s.count)
+}
+
+// lines returns the statistics as lines to display.
+func (s *columnStats) lines() []string {
+	lines := []string{
+		"column   " + s.name,
+		fmt.Sprintf("rows     %d", s.rows),
+		fmt.Sprintf("count    %d", s.count),
+		fmt.Sprintf("distinct %d", s.distinct),
+		"min      " + s.min,
+		"max      " + s.max,
+	}
+	if s.numeric && s.count > 0 {
+		lines = append(lines,
+			"sum      "+strconv.FormatFloat(s.sum, 'f', -1, 64),
+			"mean     "+strconv.FormatFloat(s.mean(), 'f', -1, 64),
+		)
+	}
+	lines = append(lines, "top")
+	return lines
+}
+
+// columnStatsScanner computes the statistics of a column
+// with the settings of the document taken on the event loop.
+type columnStatsScanner struct {
+	m        *Document
+	splitter columnSplitter
+	column   int
+	name     string
+	start    int
+}
+
+// columnStatsScanner returns the columnStatsScanner of the column with the current settings.
+func (m *Document) columnStatsScanner(column int) columnStatsScanner {
+	return columnStatsScanner{
+		m:        m,
+		splitter: m.columnSplitter(),
+		column:   column,
+		name:     m.columnLabel(column),
+		start:    m.firstLine(),
+	}
+}
+
+// columnStatistics returns the statistics of the column over the whole document.
+func (m *Document) columnStatistics(ctx context.Context, column int) (*columnStats, error) {
+	return m.columnStatsScanner(column).scan(ctx, m.BufEndNum())
+}
+
+// scan returns the statistics of the column of the lines up to end.
+func (s columnStatsScanner) scan(ctx context.Context, end int) (*columnStats, error) {
+	stats := &columnStats{
+		doc:     s.m,
+		column:  s.column,
+		name:    s.name,
+		end:     end,
+		numeric: true,
+	}
+	counts := make(map[string]int)
+	var minNum, maxNum float64
+	err := s.m.eachLine(ctx, s.start, func(lN int, line []byte) bool {
+		if lN >= end {
+			return false
+		}
+		stats.rows++
+		value := trimColumnValue(s.splitter.value(string(line), s.column))
+		if value == "" {
+			return true
+		}
+		stats.count++
+		counts[value]++
+		if stats.count == 1 {
+			stats.min, stats.max = value, value
+		}
+		stats.min = min(stats.min, value)
+		stats.max = max(stats.max, value)
+		if !stats.numeric {
+			return true
+		}
+		n, ok := parseNumber(value)
+		if !ok {
+			stats.numeric = false
+			return true
+		}
+		if stats.count == 1 {
+			minNum, maxNum = n, n
+		}
+		minNum, maxNum = min(minNum, n), max(maxNum, n)
+		stats.sum += n
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if stats.numeric && stats.count > 0 {
+		stats.min = strconv.FormatFloat(minNum, 'f', -1, 64)
+		stats.max = strconv.FormatFloat(maxNum, 'f', -1, 64)
+	}
+	stats.distinct = len(counts)
+	stats.top = topValues(counts, columnStatsTop)
+	return stats, nil
+}
+
+// topValues returns the n most frequent values.
+// Values with the same count are in lexical order.
+func topValues(counts map[string]int, n int) []valueCount {
+	values := make([]valueCount, 0, len(counts))
+	for value, count := range counts {
+		values = append(values, valueCount{value: value, count: count})
+	}
+	slices.SortFunc(values, func(a, b valueCount) int {
+		if c := cmp.Compare(b.count, a.count); c != 0 {
+			return c
+		}
+		return strings.Compare(a.value, b.value)
+	})
+	return values[:min(n, len(values))]
+}
+
+// eventColumnStats represents the event that the column statistics are computed.
+type eventColumnStats struct {
+	stats *columnStats
+	tcell.EventTime
+}
+
+// updateColumnStats computes the statistics of the cursor column in the background
+// if they are not computed yet, or lines have been added since they were computed.
+func (root *Root) updateColumnStats(ctx context.Context) {
+	m := root.Doc
+	column := m.cursorColumn()
+	if s := root.columnStats; s != nil && s.doc == m && s.column == column && s.end == m.BufEndNum() {
+		return
+	}
+	if !root.columnStatsRunning.CompareAndSwap(false, true) {
+		return
+	}
+	scanner := m.columnStatsScanner(column)
+	go func() {
+		defer root.columnStatsRunning.Store(false)
+		m.WaitEOFWithTimeout(root.Config.ReadWaitTime)
+		stats, err := scanner.scan(ctx, m.BufEndNum())
+		if err != nil {
+			log.Printf("failed to compute column statistics: %v\n", err)
+			return
+		}
+		ev := &eventColumnStats{stats: stats}
+		ev.SetEventNow()
+		root.postEvent(ev)
+	}()
+}
+
+// sidebarItemsForColumnStats returns SidebarItems for the column statistics.
+func (root *Root) sidebarItemsForColumnStats() []SidebarItem {
+	var items []SidebarItem
+	length := root.sidebarWidth - 4
+	root.updateColumnStats(context.Background())
+	stats := root.columnStats
+	if stats == nil || stats.doc != root.Doc {
+		items = append(items, root.sidebarItemForText("computing...", length))
+		return items
+	}
+	lines := stats.lines()
+	totalLines := len(lines) + len(stats.top)
+	root.adjustSidebarScroll(SidebarModeColumnStats, totalLines, 0)
+	scroll := root.sidebarScrolls[SidebarModeColumnStats]
+	start := scroll.y
+	end := min(start+root.scr.vHeight, totalLines)
+	for i := start; i < end; i++ {
+		if i < len(lines) {
+			items = append(items, root.sidebarItemForText(lines[i], length))
+			continue
+		}
+		n := i - len(lines)
+		top := stats.top[n]
+		lc := StrToContents(fmt.Sprintf("%d %s", top.count, top.value), 0)
+		if len(lc) < length {
+			spaces := StrToContents(strings.Repeat(" ", length-len(lc)), 0)
+			lc = append(lc, spaces...)
+		}
+		items = append(items, SidebarItem{
+			Label:     fmt.Sprintf("%2d ", n),
+			Contents:  lc,
+			IsCurrent: false,
+		})
+	}
+	return items
+}
+
+// toggleSidebarColumnStats toggles the column statistics sidebar visibility.
+func (root *Root) toggleSidebarColumnStats(ctx context.Context) {
+	root.toggleSidebar(ctx, SidebarModeColumnStats)
+}
+
+// columnStatsFilter filters the document by the n-th most frequent value of the column statistics.
+func (root *Root) columnStatsFilter(ctx context.Context, input string) {
+	stats := root.columnStats
+	if stats == nil || stats.doc != root.Doc {
+		root.setMessage("no column statistics")
+		return
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil || n < 0 || n >= len(stats.top) {
+		root.setMessagef("invalid value number: %s", input)
+		return
+	}
+	searcher := columnValueSearcher{
+		splitter: root.Doc.columnSplitter(),
+		column:   stats.column,
+		name:     stats.name,
+		value:    stats.top[n].value,
+	}
+	root.filterDocument(ctx, searcher, false)
+}
+
+// columnValueSearcher matches the lines whose column value equals the value.
+type columnValueSearcher struct {
+	splitter columnSplitter
+	column   int
+	name     string
+	value    string
+}
+
+// Match returns true if the column value of the line equals the value.
+func (s columnValueSearcher) Match(target []byte) bool {
+	return s.MatchString(string(target))
+}
+
+// MatchString returns true if the column value of the line equals the value.
+func (s columnValueSearcher) MatchString(target string) bool {
+	return trimColumnValue(s.splitter.value(target, s.column)) == s.value
+}
+
+// FindAll returns the position of the value in the column if the line matches.
+func (s columnValueSearcher) FindAll(target string) [][]int {
+	if s.value == "" {
+		return nil
+	}
+	str, ranges := s.splitter.ranges(target)
+	if s.column < 0 || s.column >= len(ranges) {
+		return nil
+	}
+	r := ranges[s.column]
+	if trimColumnValue(str[r[0]:r[1]]) != s.value {
+		return nil
+	}
+	i := strings.Index(str[r[0]:r[1]], s.value)
+	if i < 0 {
+		return nil
+	}
+	return [][]int{{r[0] + i, r[0] + i + len(s.value)}}
+}
+
+// String returns the column and the value.
+func (s columnValueSearcher) String() string {
+	return s.name + "=" + s.value
+}
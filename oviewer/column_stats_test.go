@@ -0,0 +1,196 @@
+package oviewer
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+func Test_topValues(t *testing.T) {
+	t.Parallel()
+	counts := map[string]int{"a": 1, "b": 3, "c": 3, "d": 2}
+	want := []valueCount{{value: "b", count: 3}, {value: "c", count: 3}, {value: "d", count: 2}}
+	if got := topValues(counts, 3); !reflect.DeepEqual(got, want) {
+		t.Errorf("topValues() = %v, want %v", got, want)
+	}
+	if got := topValues(counts, 10); len(got) != 4 {
+		t.Errorf("topValues() len = %v, want 4", len(got))
+	}
+}
+
+func TestDocument_columnStatistics(t *testing.T) {
+	tests := []struct {
+		name         string
+		column       int
+		wantCount    int
+		wantDistinct int
+		wantNumeric  bool
+		wantMin      string
+		wantMax      string
+		wantSum      float64
+		wantTop      valueCount
+	}{
+		{
+			name:         "numeric",
+			column:       2,
+			wantCount:    4,
+			wantDistinct: 4,
+			wantNumeric:  true,
+			wantMin:      "2",
+			wantMax:      "100",
+			wantSum:      121,
+			wantTop:      valueCount{value: "10", count: 1},
+		},
+		{
+			name:         "lexical",
+			column:       1,
+			wantCount:    4,
+			wantDistinct: 4,
+			wantNumeric:  false,
+			wantMin:      "-",
+			wantMax:      "512",
+			wantTop:      valueCount{value: "-", count: 1},
+		},
+		{
+			name:         "out of columns",
+			column:       5,
+			wantCount:    0,
+			wantDistinct: 0,
+			wantNumeric:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := docFileReadHelper(t, filepath.Join(testdata, "sort.csv"))
+			m.WaitEOF()
+			m.ColumnMode = true
+			m.ColumnDelimiter = ","
+			m.Header = 1
+			m.regexpCompile()
+			got, err := m.columnStatistics(context.Background(), tt.column)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.rows != 4 || got.count != tt.wantCount || got.distinct != tt.wantDistinct || got.numeric != tt.wantNumeric {
+				t.Errorf("columnStatistics() rows = %v, count = %v, distinct = %v, numeric = %v", got.rows, got.count, got.distinct, got.numeric)
+			}
+			if got.min != tt.wantMin || got.max != tt.wantMax || got.sum != tt.wantSum {
+				t.Errorf("columnStatistics() min = %v, max = %v, sum = %v", got.min, got.max, got.sum)
+			}
+			if len(got.top) > 0 && got.top[0] != tt.wantTop {
+				t.Errorf("columnStatistics() top = %v, want %v", got.top[0], tt.wantTop)
+			}
+		})
+	}
+}
+
+func TestRoot_columnStatsFilter(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "MOCK_DATA.csv"))
+	root.Doc.WaitEOF()
+	m := root.Doc
+	m.ColumnMode = true
+	m.ColumnDelimiter = ","
+	m.Header = 1
+	m.regexpCompile()
+	ctx := context.Background()
+
+	root.columnStatsFilter(ctx, "0")
+	if root.DocumentLen() != 1 {
+		t.Fatalf("columnStatsFilter() without statistics created a document")
+	}
+	stats, err := m.columnStatistics(ctx, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root.columnStats = stats
+	// The non-match toggle of the user is kept and not applied.
+	m.nonMatch = true
+	root.columnStatsFilter(ctx, "0")
+	if root.DocumentLen() != 2 {
+		t.Fatalf("columnStatsFilter() documents = %v, want 2", root.DocumentLen())
+	}
+	if !m.nonMatch {
+		t.Error("columnStatsFilter() reset nonMatch")
+	}
+	doc := root.DocList[len(root.DocList)-1]
+	doc.WaitEOF()
+	// header + matched lines.
+	if got := doc.BufEndNum(); got != stats.top[0].count+1 {
+		t.Errorf("columnStatsFilter() lines = %v, want %v", got, stats.top[0].count+1)
+	}
+	for lN := 1; lN < doc.BufEndNum(); lN++ {
+		line, err := doc.Line(lN)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v := m.columnValue(string(line), 4); v != stats.top[0].value {
+			t.Fatalf("columnStatsFilter() line %d value = %v, want %v", lN, v, stats.top[0].value)
+		}
+	}
+}
+
+func TestRoot_sidebarItemsForColumnStats(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "sort.csv"))
+	root.Doc.WaitEOF()
+	m := root.Doc
+	m.ColumnMode = true
+	m.ColumnDelimiter = ","
+	m.Header = 1
+	m.regexpCompile()
+	root.prepareScreen()
+	root.sidebarWidth = 30
+	stats, err := m.columnStatistics(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root.columnStats = stats
+	items := root.sidebarItemsForColumnStats()
+	lines := stats.lines()
+	if len(items) != len(lines)+len(stats.top) {
+		t.Fatalf("sidebarItemsForColumnStats() len = %v, want %v", len(items), len(lines)+len(stats.top))
+	}
+	top := items[len(lines)]
+	if top.Label != " 0 " || top.Contents.String() != "1 a"+strings.Repeat(" ", 23) {
+		t.Errorf("sidebarItemsForColumnStats() top = %q %q", top.Label, top.Contents.String())
+	}
+}
+
+func TestColumnValueSearcher_FindAll(t *testing.T) {
+	t.Parallel()
+	m := docHelper(t, "")
+	m.ColumnMode = true
+	m.ColumnDelimiter = ","
+	m.regexpCompile()
+	tests := []struct {
+		name   string
+		column int
+		target string
+		want   [][]int
+	}{
+		{name: "in column", column: 1, target: "b,a,c", want: [][]int{{2, 3}}},
+		{name: "earlier column", column: 1, target: "a,a,c", want: [][]int{{2, 3}}},
+		{name: "other column", column: 1, target: "a,b,c", want: nil},
+		{name: "quoted", column: 1, target: `x,"a",c`, want: [][]int{{3, 4}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := columnValueSearcher{splitter: m.columnSplitter(), column: tt.column, value: "a"}
+			if got := s.FindAll(tt.target); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
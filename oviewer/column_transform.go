@@ -0,0 +1,361 @@
+package oviewer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// transformFunc converts the value for display.
+// It returns false if the value cannot be converted.
+type transformFunc func(value string) (string, bool)
+
+// transformNone is the name to remove the transform.
+const transformNone = "none"
+
+// columnTransforms is the list of the display transforms of the columns.
+var columnTransforms = map[string]transformFunc{
+	"epoch":         epochTransform(time.Second, time.Local),
+	"epoch-utc":     epochTransform(time.Second, time.UTC),
+	"epoch-ms":      epochTransform(time.Millisecond, time.Local),
+	"epoch-ms-utc":  epochTransform(time.Millisecond, time.UTC),
+	"epoch-us":      epochTransform(time.Microsecond, time.Local),
+	"epoch-us-utc":  epochTransform(time.Microsecond, time.UTC),
+	"epoch-ns":      epochTransform(time.Nanosecond, time.Local),
+	"epoch-ns-utc":  epochTransform(time.Nanosecond, time.UTC),
+	"bytes":         bytesTransform,
+	"bytes-decimal": bytesDecimalTransform,
+	"comma":         commaTransform,
+	"duration":      durationTransform(time.Second),
+	"duration-ms":   durationTransform(time.Millisecond),
+	"duration-us":   durationTransform(time.Microsecond),
+	"duration-ns":   durationTransform(time.Nanosecond),
+}
+
+// transformNames returns the names of the transforms in order.
+func transformNames() []string {
+	names := make([]string, 0, len(columnTransforms))
+	for name := range columnTransforms {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// epochTransform returns the transform of the epoch time in the unit to the time in the location.
+func epochTransform(unit time.Duration, loc *time.Location) transformFunc {
+	layout := "2006-01-02 15:04:05"
+	switch unit {
+	case time.Millisecond:
+		layout += ".000"
+	case time.Microsecond:
+		layout += ".000000"
+	case time.Nanosecond:
+		layout += ".000000000"
+	}
+	if loc == time.UTC {
+		layout += "Z07:00"
+	}
+	return func(value string) (string, bool) {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return value, false
+		}
+		sec := n / int64(time.Second/unit)
+		nsec := (n % int64(time.Second/unit)) * int64(unit)
+		return time.Unix(sec, nsec).In(loc).Format(layout), true
+	}
+}
+
+// bytesTransform converts the number of bytes to the human-readable size in 1024 units (e.g. 1.5K).
+func bytesTransform(value string) (string, bool) {
+	return humanSize(value, 1024)
+}
+
+// bytesDecimalTransform converts the number of bytes to the human-readable size in 1000 units (e.g. 1.5K).
+func bytesDecimalTransform(value string) (string, bool) {
+	return humanSize(value, 1000)
+}
+
+// humanSize converts the number to the human-readable size in the base units.
+func humanSize(value string, base float64) (string, bool) {
+	n, ok := parseNumber(value)
+	if !ok {
+		return value, false
+	}
+	if math.Abs(n) < base {
+		return strconv.FormatFloat(n, 'f', -1, 64), true
+	}
+	unit := -1
+	for math.Abs(n) >= base && unit < len(humanSizeUnits)-1 {
+		n /= base
+		unit++
+	}
+	str := strconv.FormatFloat(n, 'f', 1, 64)
+	str = strings.TrimSuffix(str, ".0")
+	return str + string(humanSizeUnits[unit]), true
+}
+
+// commaTransform adds the thousands separators to the number.
+// Only the decimal numbers are converted, not the exponents, Inf or NaN.
+func commaTransform(value string) (string, bool) {
+	sign, number := "", value
+	if number != "" && (number[0] == '-' || number[0] == '+') {
+		sign, number = number[:1], number[1:]
+	}
+	integer, fraction, found := strings.Cut(number, ".")
+	if integer == "" || !isDigits(integer) || !isDigits(fraction) {
+		return value, false
+	}
+	var sb strings.Builder
+	sb.WriteString(sign)
+	for i, c := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteRune(c)
+	}
+	if found {
+		sb.WriteByte('.')
+		sb.WriteString(fraction)
+	}
+	return sb.String(), true
+}
+
+// isDigits returns true if the string consists of only the decimal digits.
+func isDigits(str string) bool {
+	for i := range len(str) {
+		if str[i] < '0' || str[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// durationTransform returns the transform of the number in the unit to the duration (e.g. 1h2m3s).
+func durationTransform(unit time.Duration) transformFunc {
+	return func(value string) (string, bool) {
+		n, ok := parseNumber(value)
+		if !ok {
+			return value, false
+		}
+		return time.Duration(n * float64(unit)).String(), true
+	}
+}
+
+// transformValue converts the value of the column for display.
+// The surrounding spaces and quotes are removed when converted.
+func transformValue(name string, value string) (string, bool) {
+	f, ok := columnTransforms[name]
+	if !ok {
+		return value, false
+	}
+	return f(trimColumnValue(value))
+}
+
+// parseColumnTransform parses "column:transform". The column is the index or the header name.
+func parseColumnTransform(str string, names []string) (int, string, error) {
+	i := strings.LastIndex(str, ":")
+	if i < 0 {
+		return 0, "", fmt.Errorf("%w: %s", ErrInvalidTransform, str)
+	}
+	column, name := str[:i], strings.ToLower(strings.TrimSpace(str[i+1:]))
+	if _, ok := columnTransforms[name]; !ok {
+		return 0, "", fmt.Errorf("%w: %s", ErrInvalidTransform, name)
+	}
+	n, ok := columnIndex(strings.TrimSpace(column), names)
+	if !ok {
+		return 0, "", fmt.Errorf("%w: no column %s", ErrInvalidTransform, column)
+	}
+	return n, name, nil
+}
+
+// setColumnTransform sets the transform of the column under the cursor (align mode only).
+func (root *Root) setColumnTransform(_ context.Context, input string) {
+	m := root.Doc
+	column := m.cursorColumn()
+	if err := m.isValidColumn(column); err != nil {
+		root.setMessage(err.Error())
+		return
+	}
+	name := strings.ToLower(strings.TrimSpace(input))
+	if name == "" || name == transformNone {
+		name = ""
+	} else if _, ok := columnTransforms[name]; !ok {
+		root.setMessagef("%s: %s", ErrInvalidTransform, input)
+		return
+	}
+	m.alignConv.columnAttrs[column].transform = name
+	m.ClearCache()
+	if name == "" {
+		root.setMessagef("column %s: no transform", m.columnLabel(column))
+		return
+	}
+	root.setMessagef("column %s: %s", m.columnLabel(column), name)
+}
+
+// transformKey returns the key of the transforms and the column settings, or empty if no column is transformed.
+func (m *Document) transformKey() string {
+	var sb strings.Builder
+	for column, attr := range m.alignConv.columnAttrs {
+		if attr.transform != "" {
+			fmt.Fprintf(&sb, "%d:%s,", column, attr.transform)
+		}
+	}
+	if sb.Len() == 0 {
+		return ""
+	}
+	return sb.String() + "\x00" + m.stableWidthsKey()
+}
+
+// transformedWidths returns the widths widened to the transformed columns over the lines.
+// The widths of each line are cached until the transforms or the column settings are changed.
+func (m *Document) transformedWidths(maxWidths []int, lines []int) []int {
+	key := m.transformKey()
+	if key == "" {
+		return maxWidths
+	}
+	if m.transformWidths == nil || m.transformWidthsKey != key {
+		cache, err := lru.New[int, []int](DocumentCacheSize)
+		if err != nil {
+			return maxWidths
+		}
+		m.transformWidths = cache
+		m.transformWidthsKey = key
+	}
+	for _, lN := range lines {
+		widths, ok := m.transformWidths.Get(lN)
+		if !ok {
+			widths, ok = m.lineTransformedWidths(lN)
+			if !ok {
+				continue
+			}
+			m.transformWidths.Add(lN, widths)
+		}
+		for column, width := range widths {
+			if column < len(maxWidths) {
+				maxWidths[column] = max(maxWidths[column], width)
+			}
+		}
+	}
+	return maxWidths
+}
+
+// lineTransformedWidths returns the widths of the transformed columns of the line.
+func (m *Document) lineTransformedWidths(lN int) ([]int, bool) {
+	line, err := m.Line(lN)
+	if err != nil {
+		return nil, false
+	}
+	values := m.columnValues(string(line))
+	widths := make([]int, len(m.alignConv.columnAttrs))
+	for column, attr := range m.alignConv.columnAttrs {
+		if attr.transform == "" || column >= len(values) {
+			continue
+		}
+		if v, ok := transformValue(attr.transform, values[column]); ok {
+			widths[column] = stringWidth(v)
+		}
+	}
+	return widths, true
+}
+
+// columnTransformer converts the transformed columns of the lines
+// with the settings of the document taken on the event loop.
+type columnTransformer struct {
+	splitter   columnSplitter
+	transforms []string
+}
+
+// columnTransformer returns the columnTransformer of the document.
+// It returns false if the transforms are not applied to search and export, or no column is transformed.
+func (m *Document) columnTransformer() (columnTransformer, bool) {
+	if !m.ApplyTransform || m.Converter != convAlign {
+		return columnTransformer{}, false
+	}
+	transforms := make([]string, len(m.alignConv.columnAttrs))
+	found := false
+	for column, attr := range m.alignConv.columnAttrs {
+		transforms[column] = attr.transform
+		found = found || attr.transform != ""
+	}
+	if !found {
+		return columnTransformer{}, false
+	}
+	return columnTransformer{splitter: m.columnSplitter(), transforms: transforms}, true
+}
+
+// value returns the value of the column converted by its transform.
+func (t columnTransformer) value(column int, value string) string {
+	if column >= len(t.transforms) || t.transforms[column] == "" {
+		return value
+	}
+	if v, ok := transformValue(t.transforms[column], value); ok {
+		return v
+	}
+	return value
+}
+
+// line returns the line whose transformed columns are converted.
+// The escape sequences of the line are removed.
+func (t columnTransformer) line(line string) string {
+	str, ranges := t.splitter.ranges(line)
+	var sb strings.Builder
+	start := 0
+	for column, r := range ranges {
+		sb.WriteString(str[start:r[0]])
+		sb.WriteString(t.value(column, str[r[0]:r[1]]))
+		start = r[1]
+	}
+	sb.WriteString(str[start:])
+	return sb.String()
+}
+
+// transformSearcher is a Searcher that matches the lines whose transformed columns are converted.
+type transformSearcher struct {
+	Searcher
+	transformer columnTransformer
+}
+
+// Match returns true if the converted line matches.
+func (s transformSearcher) Match(target []byte) bool {
+	return s.Searcher.MatchString(s.transformer.line(string(target)))
+}
+
+// MatchString returns true if the converted line matches.
+func (s transformSearcher) MatchString(target string) bool {
+	return s.Searcher.MatchString(s.transformer.line(target))
+}
+
+// transformSearcher returns the searcher that matches the transformed values
+// if ApplyTransform is enabled for the current document.
+func (root *Root) transformSearcher(searcher Searcher) Searcher {
+	if root.Doc == nil || searcher == nil {
+		return searcher
+	}
+	t, ok := root.Doc.columnTransformer()
+	if !ok {
+		return searcher
+	}
+	return transformSearcher{Searcher: searcher, transformer: t}
+}
+
+// originalValue returns the value of the current row before the transform
+// if the column is transformed.
+func (root *Root) originalValue(column int) (string, bool) {
+	m := root.Doc
+	if m.isValidColumn(column) != nil || m.alignConv.columnAttrs[column].transform == "" {
+		return "", false
+	}
+	line, err := m.Line(root.recordLineNumber())
+	if err != nil {
+		return "", false
+	}
+	return trimColumnValue(m.columnValue(string(line), column)), true
+}
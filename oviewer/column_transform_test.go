@@ -0,0 +1,182 @@
+package oviewer
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+func Test_transformValue(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		value  string
+		want   string
+		wantOK bool
+	}{
+		{name: "epoch-utc", value: "1700000000", want: "2023-11-14 22:13:20Z", wantOK: true},
+		{name: "epoch-ms-utc", value: "1700000000123", want: "2023-11-14 22:13:20.123Z", wantOK: true},
+		{name: "epoch-ns-utc", value: "1700000000000000001", want: "2023-11-14 22:13:20.000000001Z", wantOK: true},
+		{name: "epoch-utc", value: "now", want: "now", wantOK: false},
+		{name: "bytes", value: "512", want: "512", wantOK: true},
+		{name: "bytes", value: "1536", want: "1.5K", wantOK: true},
+		{name: "bytes", value: " 1048576 ", want: "1M", wantOK: true},
+		{name: "bytes-decimal", value: "1500", want: "1.5K", wantOK: true},
+		{name: "bytes", value: "-", want: "-", wantOK: false},
+		{name: "comma", value: "1234567", want: "1,234,567", wantOK: true},
+		{name: "comma", value: "-1234.5", want: "-1,234.5", wantOK: true},
+		{name: "comma", value: "123", want: "123", wantOK: true},
+		{name: "comma", value: "abc", want: "abc", wantOK: false},
+		{name: "comma", value: "Infinity", want: "Infinity", wantOK: false},
+		{name: "comma", value: "-Inf", want: "-Inf", wantOK: false},
+		{name: "comma", value: "NaN", want: "NaN", wantOK: false},
+		{name: "comma", value: "1e10", want: "1e10", wantOK: false},
+		{name: "duration", value: "3723", want: "1h2m3s", wantOK: true},
+		{name: "duration-ms", value: "1500", want: "1.5s", wantOK: true},
+		{name: "unknown", value: "1", want: "1", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.value, func(t *testing.T) {
+			t.Parallel()
+			got, ok := transformValue(tt.name, tt.value)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("transformValue() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func Test_parseColumnTransform(t *testing.T) {
+	t.Parallel()
+	names := []string{"name", "time", "size"}
+	tests := []struct {
+		str        string
+		wantColumn int
+		wantName   string
+		wantErr    error
+	}{
+		{str: "size:bytes", wantColumn: 2, wantName: "bytes"},
+		{str: "1:Epoch-UTC", wantColumn: 1, wantName: "epoch-utc"},
+		{str: "size", wantErr: ErrInvalidTransform},
+		{str: "size:unknown", wantErr: ErrInvalidTransform},
+		{str: "none:bytes", wantErr: ErrInvalidTransform},
+	}
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			t.Parallel()
+			column, name, err := parseColumnTransform(tt.str, names)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseColumnTransform() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if column != tt.wantColumn || name != tt.wantName {
+				t.Errorf("parseColumnTransform() = %v, %v, want %v, %v", column, name, tt.wantColumn, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestRoot_columnTransform(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "transform.csv"))
+	root.Doc.WaitEOF()
+	m := root.Doc
+	m.ColumnMode = true
+	m.ColumnDelimiter = ","
+	m.Header = 1
+	m.Converter = convAlign
+	m.ColumnTransform = []string{"size:bytes", "elapsed:duration"}
+	m.regexpCompile()
+	ctx := context.Background()
+	root.prepareScreen()
+	root.prepareDraw(ctx)
+	root.prepareDraw(ctx)
+
+	tests := []struct {
+		lN   int
+		want string
+	}{
+		{lN: 0, want: "name,time      ,size   ,elapsed"},
+		{lN: 1, want: "a   ,1700000000,1.5K   ,1h2m3s"},
+		{lN: 2, want: "bb  ,1700000001,1M     ,59s"},
+	}
+	for _, tt := range tests {
+		if got := strings.TrimRight(root.scr.lines[tt.lN].lc.String(), " "); got != tt.want {
+			t.Errorf("column transform line %d = %q, want %q", tt.lN, got, tt.want)
+		}
+	}
+
+	// The original value is displayed for the transformed column.
+	m.columnCursor = 2
+	root.columnNameMessage()
+	if root.message != "column size: 1536" {
+		t.Errorf("columnNameMessage() = %q, want %q", root.message, "column size: 1536")
+	}
+	if got := root.columnStatus(); got != "[2:size=1536]" {
+		t.Errorf("columnStatus() = %q, want %q", got, "[2:size=1536]")
+	}
+
+	m.columnCursor = 1
+	root.setColumnTransform(ctx, "epoch-utc")
+	if m.alignConv.columnAttrs[1].transform != "epoch-utc" {
+		t.Errorf("setColumnTransform() transform = %q", m.alignConv.columnAttrs[1].transform)
+	}
+	root.setColumnTransform(ctx, "none")
+	if m.alignConv.columnAttrs[1].transform != "" {
+		t.Errorf("setColumnTransform() none transform = %q", m.alignConv.columnAttrs[1].transform)
+	}
+	root.setColumnTransform(ctx, "unknown")
+	if !strings.Contains(root.message, ErrInvalidTransform.Error()) {
+		t.Errorf("setColumnTransform() message = %q", root.message)
+	}
+}
+
+func TestRoot_applyTransform(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "transform.csv"))
+	root.Doc.WaitEOF()
+	m := root.Doc
+	m.ColumnMode = true
+	m.ColumnDelimiter = ","
+	m.Header = 1
+	m.Converter = convAlign
+	m.ColumnTransform = []string{"size:bytes"}
+	m.regexpCompile()
+	ctx := context.Background()
+	root.prepareScreen()
+	root.prepareDraw(ctx)
+
+	searcher := root.createSearcher("1.5K", false)
+	if searcher.MatchString("a,1700000000,1536,3723") {
+		t.Errorf("createSearcher() matched the transformed value without ApplyTransform")
+	}
+	table, err := m.tableToExport(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := table.rows[0][2]; got != "1536" {
+		t.Errorf("tableToExport() = %q, want %q", got, "1536")
+	}
+
+	m.ApplyTransform = true
+	searcher = root.createSearcher("1.5K", false)
+	if !searcher.Match([]byte("a,1700000000,1536,3723")) {
+		t.Errorf("createSearcher() did not match the transformed value with ApplyTransform")
+	}
+	table, err = m.tableToExport(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := table.rows[0][2]; got != "1.5K" {
+		t.Errorf("tableToExport() = %q, want %q", got, "1.5K")
+	}
+}
@@ -0,0 +1,118 @@
+package oviewer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// columnSplitter splits the lines into the columns with the column settings of the document.
+// It is taken on the event loop so that the lines can be split in the background.
+type columnSplitter struct {
+	tabWidth     int
+	columnMode   bool
+	columnWidth  bool
+	columnWidths []int
+	delimiter    string
+	delimiterReg *regexp.Regexp
+	columnReg    *regexp.Regexp
+}
+
+// columnSplitter returns the columnSplitter with the current column settings of the document.
+func (m *Document) columnSplitter() columnSplitter {
+	return columnSplitter{
+		tabWidth:     m.TabWidth,
+		columnMode:   m.ColumnMode,
+		columnWidth:  m.ColumnWidth,
+		columnWidths: m.columnWidths,
+		delimiter:    m.ColumnDelimiter,
+		delimiterReg: m.ColumnDelimiterReg,
+		columnReg:    m.ColumnRegexpReg,
+	}
+}
+
+// columnValues returns the values of the columns of the line.
+// The line is split in the same way as the column mode displays it.
+// If the column mode is not enabled, the whole line is one column.
+func (m *Document) columnValues(line string) []string {
+	return m.columnSplitter().values(line)
+}
+
+// columnValue returns the value of the column of the line.
+// It returns an empty string if the line does not have the column.
+func (m *Document) columnValue(line string, column int) string {
+	return m.columnSplitter().value(line, column)
+}
+
+// values returns the values of the columns of the line.
+func (s columnSplitter) values(line string) []string {
+	str, ranges := s.ranges(line)
+	values := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		value := str[r[0]:r[1]]
+		if s.columnMode && s.columnWidth {
+			value = strings.TrimSpace(value)
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// value returns the value of the column of the line.
+// It returns an empty string if the line does not have the column.
+func (s columnSplitter) value(line string, column int) string {
+	values := s.values(line)
+	if column < 0 || column >= len(values) {
+		return ""
+	}
+	return values[column]
+}
+
+// ranges returns the line without the escape sequences and the byte ranges of the columns in it.
+func (s columnSplitter) ranges(line string) (string, [][]int) {
+	lc := StrToContents(line, s.tabWidth)
+	str, _ := ContentsToStr(lc)
+	if !s.columnMode {
+		return str, [][]int{{0, len(str)}}
+	}
+	if s.columnWidth {
+		return str, columnWidthRanges(lc, s.columnWidths)
+	}
+	start, indexes := columnDelimiterIndex(str, s.delimiter, s.delimiterReg, s.columnReg)
+	ranges := make([][]int, 0, len(indexes)+1)
+	for _, idx := range indexes {
+		ranges = append(ranges, []int{start, idx[0]})
+		start = idx[1]
+	}
+	if s.columnReg == nil {
+		ranges = append(ranges, []int{start, len(str)})
+	}
+	return str, ranges
+}
+
+// columnWidthRanges returns the byte ranges of the columns split by the column widths.
+func columnWidthRanges(lc contents, widths []int) [][]int {
+	if len(widths) == 0 {
+		return [][]int{{0, len(lc.String())}}
+	}
+	ranges := make([][]int, 0, len(widths)+1)
+	start := 0
+	for c := range len(widths) + 1 {
+		end := findColumnEnd(lc, widths, c, start)
+		if start > end {
+			break
+		}
+		byteStart := len(lc[:start].String())
+		ranges = append(ranges, []int{byteStart, byteStart + len(lc[start:end].String())})
+		start = end + 1
+	}
+	return ranges
+}
+
+// trimColumnValue returns the value without the surrounding spaces and quotes.
+func trimColumnValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		value = strings.ReplaceAll(value[1:len(value)-1], `""`, `"`)
+	}
+	return value
+}
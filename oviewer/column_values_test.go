@@ -0,0 +1,86 @@
+package oviewer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDocument_columnValues(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		mode      bool
+		delimiter string
+		regexp    string
+		line      string
+		want      []string
+	}{
+		{
+			name: "no column mode",
+			line: "a,b,c",
+			want: []string{"a,b,c"},
+		},
+		{
+			name:      "delimiter",
+			mode:      true,
+			delimiter: ",",
+			line:      "a,b,",
+			want:      []string{"a", "b", ""},
+		},
+		{
+			name:      "quoted",
+			mode:      true,
+			delimiter: ",",
+			line:      `a,"b,c",d`,
+			want:      []string{"a", `"b,c"`, "d"},
+		},
+		{
+			name:      "escape sequence",
+			mode:      true,
+			delimiter: ",",
+			line:      "\x1b[31ma\x1b[m,b",
+			want:      []string{"a", "b"},
+		},
+		{
+			name:   "regexp",
+			mode:   true,
+			regexp: `^(?P<ip>\S+) (?P<status>\d+)`,
+			line:   "127.0.0.1 200 extra",
+			want:   []string{"127.0.0.1", "200"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := docHelper(t, "")
+			m.ColumnMode = tt.mode
+			m.ColumnDelimiter = tt.delimiter
+			m.ColumnRegexp = tt.regexp
+			m.regexpCompile()
+			if got := m.columnValues(tt.line); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Document.columnValues() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_trimColumnValue(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: " a ", want: "a"},
+		{value: `"a,b"`, want: "a,b"},
+		{value: `"say ""hi"""`, want: `say "hi"`},
+		{value: `"`, want: `"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+			if got := trimColumnValue(tt.value); got != tt.want {
+				t.Errorf("trimColumnValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -62,8 +62,24 @@ type Config struct {
 	SmartCaseSensitive bool
 	// RegexpSearch indicates whether to use regular expression search.
 	RegexpSearch bool
+	// NormalizeSearch indicates whether to search after NFKC normalization
+	// of both the search pattern and the text.
+	NormalizeSearch bool
+	// FoldDiacritics indicates whether to ignore diacritical marks in search.
+	FoldDiacritics bool
 	// Incsearch indicates whether to use incremental search.
 	Incsearch bool
+	// SearchBackground indicates whether to search in the background
+	// without blocking the screen update.
+	SearchBackground bool
+	// AutoDetect indicates whether to detect the format of the input and apply the matching view mode.
+	AutoDetect bool
+	// Preprocessors are the commands that convert the input files before they are read.
+	// The first preprocessor that matches the file is used.
+	Preprocessors []Preprocessor
+	// LessOpen indicates whether to preprocess the input files with the LESSOPEN environment variable
+	// when no preprocessor matches.
+	LessOpen bool
 	// NotifyEOF specifies the number of times to notify EOF.
 	NotifyEOF int
 
@@ -79,6 +95,14 @@ type Config struct {
 
 	// Editor is the editor command to use for editing files.
 	Editor string
+	// LinkOpener is the command to open URLs. The URL is appended to the command.
+	// If empty, the default command of the OS is used.
+	LinkOpener string
+	// DisableLinkOpen indicates whether to disable opening links.
+	DisableLinkOpen bool
+	// LinkOpenFile indicates whether to open the file URLs and the file:line links.
+	// They are refused by default because the links come from the content of the document.
+	LinkOpenFile bool
 	// ShrinkChar specifies the character to display when the column is shrunk.
 	ShrinkChar string
 	// DisableColumnCycle indicates whether to disable column cycling.
@@ -212,6 +236,44 @@ type StyleConfig struct {
 	SelectCopied *OVStyle
 	// PauseLine is the style that applies to the line where follow mode is paused.
 	PauseLine *OVStyle
+	// ColumnUnmatched is the style that applies to the line that does not match ColumnRegexp.
+	ColumnUnmatched *OVStyle
+	// DiffAdded is the style that applies to the added lines in the diff mode.
+	DiffAdded *OVStyle
+	// DiffRemoved is the style that applies to the removed lines in the diff mode.
+	DiffRemoved *OVStyle
+	// DiffFile is the style that applies to the file headers in the diff mode.
+	DiffFile *OVStyle
+	// DiffHunk is the style that applies to the hunk headers in the diff mode.
+	DiffHunk *OVStyle
+	// DiffWord is the style that applies to the changed words in the diff mode.
+	DiffWord *OVStyle
+	// SyntaxKeyword is the style that applies to the keywords in the syntax highlighting.
+	SyntaxKeyword *OVStyle
+	// SyntaxString is the style that applies to the strings in the syntax highlighting.
+	SyntaxString *OVStyle
+	// SyntaxNumber is the style that applies to the numbers in the syntax highlighting.
+	SyntaxNumber *OVStyle
+	// SyntaxComment is the style that applies to the comments in the syntax highlighting.
+	SyntaxComment *OVStyle
+	// SyntaxKey is the style that applies to the keys of YAML, JSON and TOML in the syntax highlighting.
+	SyntaxKey *OVStyle
+	// MarkdownHeading is the style that applies to the headings by level in the markdown converter.
+	MarkdownHeading *[]OVStyle
+	// MarkdownCode is the style that applies to the code spans and the code blocks in the markdown converter.
+	MarkdownCode *OVStyle
+	// MarkdownLink is the style that applies to the links in the markdown converter.
+	MarkdownLink *OVStyle
+	// MarkdownMark is the style that applies to the bullets, quote bars, rules and table borders in the markdown converter.
+	MarkdownMark *OVStyle
+	// JSONKey is the style that applies to the object keys in the json converter.
+	JSONKey *OVStyle
+	// JSONString is the style that applies to the string values in the json converter.
+	JSONString *OVStyle
+	// JSONNumber is the style that applies to the number values in the json converter.
+	JSONNumber *OVStyle
+	// JSONLiteral is the style that applies to true, false and null in the json converter.
+	JSONLiteral *OVStyle
 }
 
 // deprecatedStyleConfig is the old style setting.
@@ -113,6 +113,33 @@ func (m *Document) ControlLog() error {
 	return nil
 }
 
+// ControlRender controls the document whose lines are written directly, not read by a reader.
+// There is nothing to read, so only closing is processed.
+func (m *Document) ControlRender() error {
+	go func() {
+		for sc := range m.ctlCh {
+			m.controlRender(sc)
+			if sc.done != nil {
+				sc.done <- true
+				close(sc.done)
+			}
+		}
+		log.Println("close m.ctlCh")
+	}()
+	return nil
+}
+
+// controlRender executes the request of the document written directly.
+func (m *Document) controlRender(sc controlSpecifier) {
+	switch sc.request {
+	case requestLoad, requestFollow, requestReload, requestSearch:
+	case requestClose:
+		m.close()
+	default:
+		panic(fmt.Sprintf("unexpected %s", sc.request))
+	}
+}
+
 // controlFile controls file read and loads in chunks.
 // controlFile receives and executes request.
 func (m *Document) controlFile(sc controlSpecifier, reader *bufio.Reader) (*bufio.Reader, error) {
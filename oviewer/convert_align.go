@@ -2,6 +2,7 @@ package oviewer
 
 import (
 	"regexp"
+	"slices"
 
 	"github.com/rivo/uniseg"
 )
@@ -13,9 +14,14 @@ type align struct {
 	orgWidths    []int // Original width of each column. This is the width determined by Guesswidth.
 	maxWidths    []int // Maximum width of each column.
 	columnAttrs  []columnAttribute
+	columnOrder  []int    // Display order of the columns. Columns not included follow in their original order.
+	appliedHide  []string // ColumnHide applied to columnAttrs.
+	appliedOrder []string // ColumnOrder applied to columnOrder.
+	appliedTrans []string // ColumnTransform applied to columnAttrs.
 	WidthF       bool
 	delimiter    string
 	delimiterReg *regexp.Regexp
+	columnReg    *regexp.Regexp
 	count        int
 }
 
@@ -49,6 +55,8 @@ type columnAttribute struct {
 	shrink         bool           // Shrink column.
 	specifiedAlign specifiedAlign // Alignment specification for the column.
 	rightAlign     bool           // Right align column.
+	hidden         bool           // Hidden column.
+	transform      string         // Name of the display transform.
 }
 
 func newAlignConverter(widthF bool) *align {
@@ -71,6 +79,7 @@ func (a *align) clone() *align {
 		WidthF:       a.WidthF,
 		delimiter:    a.delimiter,
 		delimiterReg: a.delimiterReg,
+		columnReg:    a.columnReg,
 		count:        0,
 	}
 	if len(a.orgWidths) > 0 {
@@ -82,6 +91,9 @@ func (a *align) clone() *align {
 	if len(a.columnAttrs) > 0 {
 		clone.columnAttrs = append([]columnAttribute(nil), a.columnAttrs...)
 	}
+	if len(a.columnOrder) > 0 {
+		clone.columnOrder = append([]int(nil), a.columnOrder...)
+	}
 
 	return clone
 }
@@ -113,9 +125,14 @@ func (a *align) convert(st *parseState) bool {
 		return false
 	}
 
-	if a.WidthF {
+	switch {
+	case a.isReordered() && a.WidthF:
+		st.lc = a.convertWidthOrder(st.lc)
+	case a.isReordered():
+		st.lc = a.convertDelmOrder(st.lc)
+	case a.WidthF:
 		st.lc = a.convertWidth(st.lc)
-	} else {
+	default:
 		st.lc = a.convertDelm(st.lc)
 	}
 	return false
@@ -129,12 +146,14 @@ func (a *align) reset() {
 // convertDelm works line by line.
 func (a *align) convertDelm(src contents) contents {
 	str, pos := ContentsToStr(src)
-	indexes := allIndex(str, a.delimiter, a.delimiterReg)
+	first, indexes := columnDelimiterIndex(str, a.delimiter, a.delimiterReg, a.columnReg)
 	if len(indexes) == 0 {
 		return src
 	}
+	start := pos.x(first)
+	// Add the text before the first column as it is.
 	dst := make(contents, 0, len(src))
-	start := pos.x(0)
+	dst = append(dst, src[:start]...)
 	for columnNum := range indexes {
 		end := pos.x(indexes[columnNum][0]) // Start of the delimiter.
 		delmEnd := pos.x(indexes[columnNum][1])
@@ -199,8 +218,81 @@ func (a *align) convertWidth(src contents) contents {
 	return dst
 }
 
+// convertDelmOrder aligns the columns split by the delimiter in the display order.
+// Hidden columns are removed together with the delimiter.
+func (a *align) convertDelmOrder(src contents) contents {
+	str, pos := ContentsToStr(src)
+	first, indexes := columnDelimiterIndex(str, a.delimiter, a.delimiterReg, a.columnReg)
+	if len(indexes) == 0 {
+		return src
+	}
+	columns := make([]contents, 0, len(indexes)+1)
+	delimiters := make([]contents, 0, len(indexes))
+	start := pos.x(first)
+	prefix := src[:start]
+	for columnNum := range indexes {
+		end := pos.x(indexes[columnNum][0])
+		delmEnd := pos.x(indexes[columnNum][1])
+		columns = append(columns, src[start:end])
+		delimiters = append(delimiters, src[end:delmEnd])
+		start = delmEnd
+	}
+	columns = append(columns, src[start:])
+
+	// Add the text before the first column as it is.
+	dst := make(contents, 0, len(src))
+	dst = append(dst, prefix...)
+	display := a.displayColumns(len(columns))
+	for i, columnNum := range display {
+		if i > 0 {
+			dst = append(dst, delimiters[i-1]...)
+		}
+		if a.isShrink(columnNum) {
+			dst = appendShrink(dst)
+			continue
+		}
+		dst = a.appendColumn(dst, columnNum, columns[columnNum])
+	}
+	return dst
+}
+
+// convertWidthOrder aligns the columns split by the width in the display order.
+// Hidden columns are removed.
+func (a *align) convertWidthOrder(src contents) contents {
+	columns := make([]contents, 0, len(a.orgWidths)+1)
+	start := 0
+	for columnNum := range a.orgWidths {
+		end := findColumnEnd(src, a.orgWidths, columnNum, start) + 1
+		end = min(end, len(src))
+		tStart := findStartWithTrim(src, start)
+		tEnd := findEndWidthTrim(src, end)
+		if tStart >= tEnd {
+			columns = append(columns, nil)
+		} else {
+			columns = append(columns, src[tStart:tEnd])
+		}
+		start = end
+	}
+	columns = append(columns, src[min(start, len(src)):])
+
+	dst := make(contents, 0, len(src))
+	display := a.displayColumns(len(columns))
+	for i, columnNum := range display {
+		if i > 0 {
+			dst = append(dst, SpaceContent)
+		}
+		if a.isShrink(columnNum) {
+			dst = appendShrink(dst)
+			continue
+		}
+		dst = a.appendColumn(dst, columnNum, columns[columnNum])
+	}
+	return dst
+}
+
 // appendColumn adds column content to the lc.
 func (a *align) appendColumn(lc contents, columnNum int, column contents) contents {
+	column = a.transformColumn(columnNum, column)
 	padding := 0
 	if columnNum < len(a.maxWidths) {
 		padding = (a.maxWidths[columnNum] - len(column))
@@ -220,6 +312,25 @@ func (a *align) appendColumn(lc contents, columnNum int, column contents) conten
 	return lc
 }
 
+// transformColumn returns the column converted by the transform of the column.
+// The style of the first character is applied to the converted value.
+func (a *align) transformColumn(columnNum int, column contents) contents {
+	if columnNum < 0 || columnNum >= len(a.columnAttrs) || a.columnAttrs[columnNum].transform == "" {
+		return column
+	}
+	value, ok := transformValue(a.columnAttrs[columnNum].transform, column.String())
+	if !ok {
+		return column
+	}
+	lc := StrToContents(value, 0)
+	if l, _ := trimmedIndices(column); l < len(column) {
+		for i := range lc {
+			lc[i].style = column[l].style
+		}
+	}
+	return lc
+}
+
 // appendShrink adds a shrink column to the lc.
 func appendShrink(lc contents) contents {
 	lc = append(lc, ShrinkContent)
@@ -259,6 +370,99 @@ func (a *align) isRightAlign(col int) bool {
 	return a.columnAttrs[col].rightAlign
 }
 
+// isHidden returns true if the column is hidden.
+func (a *align) isHidden(col int) bool {
+	if col < 0 || col >= len(a.columnAttrs) {
+		return false
+	}
+	return a.columnAttrs[col].hidden
+}
+
+// isReordered returns true if the columns are hidden or reordered.
+func (a *align) isReordered() bool {
+	if len(a.columnOrder) > 0 {
+		return true
+	}
+	for _, attr := range a.columnAttrs {
+		if attr.hidden {
+			return true
+		}
+	}
+	return false
+}
+
+// orderedColumns returns all column numbers less than num in the display order, including hidden columns.
+func (a *align) orderedColumns(num int) []int {
+	columns := make([]int, 0, num)
+	seen := make([]bool, num)
+	for _, col := range a.columnOrder {
+		if col < 0 || col >= num || seen[col] {
+			continue
+		}
+		seen[col] = true
+		columns = append(columns, col)
+	}
+	for col := range num {
+		if !seen[col] {
+			columns = append(columns, col)
+		}
+	}
+	return columns
+}
+
+// displayColumns returns the column numbers less than num to display in order.
+func (a *align) displayColumns(num int) []int {
+	columns := a.orderedColumns(num)
+	display := columns[:0]
+	for _, col := range columns {
+		if !a.isHidden(col) {
+			display = append(display, col)
+		}
+	}
+	return display
+}
+
+// displayWidths returns the maximum widths of the columns in the display order.
+func (a *align) displayWidths() []int {
+	if !a.isReordered() {
+		return a.maxWidths
+	}
+	widths := make([]int, 0, len(a.maxWidths))
+	for _, col := range a.displayColumns(len(a.maxWidths)) {
+		widths = append(widths, a.maxWidths[col])
+	}
+	return widths
+}
+
+// columnNum returns the original column number of the column displayed at the cursor.
+// It returns the cursor as it is if the cursor is out of the columns.
+func (a *align) columnNum(cursor int) int {
+	if !a.isReordered() {
+		return cursor
+	}
+	display := a.displayColumns(len(a.columnAttrs))
+	if cursor < 0 || cursor >= len(display) {
+		return cursor
+	}
+	return display[cursor]
+}
+
+// moveColumn moves the column displayed at the cursor by n in the display order.
+// It returns the new cursor position.
+func (a *align) moveColumn(cursor int, n int) (int, error) {
+	display := a.displayColumns(len(a.columnAttrs))
+	to := cursor + n
+	if cursor < 0 || cursor >= len(display) || to < 0 || to >= len(display) {
+		return cursor, ErrNoColumnSelected
+	}
+	columns := a.orderedColumns(len(a.columnAttrs))
+	from := slices.Index(columns, display[cursor])
+	dest := slices.Index(columns, display[to])
+	columns[from], columns[dest] = columns[dest], columns[from]
+	a.columnOrder = columns
+	return to, nil
+}
+
 func findStartWithTrim(lc contents, s int) int {
 	for ; s < len(lc) && lc[s].str == " "; s++ {
 	}
@@ -166,6 +166,7 @@ func Test_align_convertDelm(t *testing.T) {
 		WidthF       bool
 		delimiter    string
 		delimiterReg *regexp.Regexp
+		columnReg    *regexp.Regexp
 		count        int
 	}
 	type args struct {
@@ -290,6 +291,18 @@ func Test_align_convertDelm(t *testing.T) {
 			},
 			want: " a,b ,c ,d ,e ,…",
 		},
+		{
+			name: "convertAlignRegexpPrefix",
+			fields: fields{
+				es:        newESConverter(),
+				maxWidths: []int{5, 3},
+				columnReg: regexp.MustCompile(`^\[(?P<level>\w+)\] (?P<msg>\w+)`),
+			},
+			args: args{
+				src: StrToContents("[INFO] ok", 8),
+			},
+			want: "[INFO ] ok ",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -301,6 +314,7 @@ func Test_align_convertDelm(t *testing.T) {
 				WidthF:       tt.fields.WidthF,
 				delimiter:    tt.fields.delimiter,
 				delimiterReg: tt.fields.delimiterReg,
+				columnReg:    tt.fields.columnReg,
 				count:        tt.fields.count,
 			}
 			got := a.convertDelm(tt.args.src)
@@ -581,3 +595,142 @@ func Test_appendShrink(t *testing.T) {
 		})
 	}
 }
+
+func Test_align_convertDelmOrder(t *testing.T) {
+	type fields struct {
+		maxWidths   []int
+		columns     []columnAttribute
+		columnOrder []int
+		delimiter   string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		src    string
+		want   string
+	}{
+		{
+			name: "order",
+			fields: fields{
+				maxWidths:   []int{2, 2, 2},
+				columnOrder: []int{2, 0},
+				delimiter:   ",",
+			},
+			src:  "a,b,c",
+			want: "c ,a ,b ",
+		},
+		{
+			name: "hide",
+			fields: fields{
+				maxWidths: []int{2, 2, 2},
+				columns:   []columnAttribute{{}, {hidden: true}, {}},
+				delimiter: ",",
+			},
+			src:  "a,b,c",
+			want: "a ,c ",
+		},
+		{
+			name: "order hide shrink",
+			fields: fields{
+				maxWidths:   []int{2, 2, 2, 2},
+				columns:     []columnAttribute{{hidden: true}, {shrink: true}, {}, {}},
+				columnOrder: []int{3, 1},
+				delimiter:   ",",
+			},
+			src:  "a,b,c,d",
+			want: "d ,…,c ",
+		},
+		{
+			name: "short line",
+			fields: fields{
+				maxWidths:   []int{2, 2, 2},
+				columnOrder: []int{2, 1, 0},
+				delimiter:   ",",
+			},
+			src:  "a,b",
+			want: "b ,a ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &align{
+				es:          newESConverter(),
+				maxWidths:   tt.fields.maxWidths,
+				columnAttrs: tt.fields.columns,
+				columnOrder: tt.fields.columnOrder,
+				delimiter:   tt.fields.delimiter,
+			}
+			got := a.convertDelmOrder(StrToContents(tt.src, 8))
+			if gotStr, _ := ContentsToStr(got); gotStr != tt.want {
+				t.Errorf("align.convertDelmOrder() = %q, want %q", gotStr, tt.want)
+			}
+		})
+	}
+}
+
+func Test_align_convertWidthOrder(t *testing.T) {
+	a := &align{
+		es:          newESConverter(),
+		maxWidths:   []int{1, 1, 1},
+		orgWidths:   []int{2, 5},
+		columnAttrs: []columnAttribute{{}, {hidden: true}, {}},
+		columnOrder: []int{2},
+		WidthF:      true,
+	}
+	got := a.convertWidthOrder(StrToContents("a  b  c", 8))
+	if gotStr, _ := ContentsToStr(got); gotStr != "c a" {
+		t.Errorf("align.convertWidthOrder() = %q, want %q", gotStr, "c a")
+	}
+}
+
+func Test_align_moveColumn(t *testing.T) {
+	tests := []struct {
+		name       string
+		columns    []columnAttribute
+		order      []int
+		cursor     int
+		n          int
+		wantCursor int
+		wantOrder  []int
+		wantErr    bool
+	}{
+		{
+			name:       "right",
+			columns:    make([]columnAttribute, 3),
+			cursor:     0,
+			n:          1,
+			wantCursor: 1,
+			wantOrder:  []int{1, 0, 2},
+		},
+		{
+			name:       "left over hidden",
+			columns:    []columnAttribute{{}, {hidden: true}, {}},
+			cursor:     1,
+			n:          -1,
+			wantCursor: 0,
+			wantOrder:  []int{2, 1, 0},
+		},
+		{
+			name:       "out of range",
+			columns:    make([]columnAttribute, 3),
+			order:      []int{2},
+			cursor:     0,
+			n:          -1,
+			wantCursor: 0,
+			wantOrder:  []int{2},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &align{columnAttrs: tt.columns, columnOrder: tt.order}
+			got, err := a.moveColumn(tt.cursor, tt.n)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("align.moveColumn() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.wantCursor || !reflect.DeepEqual(a.columnOrder, tt.wantOrder) {
+				t.Errorf("align.moveColumn() = %v, %v, want %v, %v", got, a.columnOrder, tt.wantCursor, tt.wantOrder)
+			}
+		})
+	}
+}
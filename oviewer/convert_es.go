@@ -45,6 +45,18 @@ const (
 type escapeSequence struct {
 	parameter strings.Builder
 	state     int
+	// promptMarks is the list of the shell integration marks (OSC 133) in the line.
+	promptMarks []promptMark
+}
+
+// promptMark is the semantic prompt mark of the shell integration (OSC 133).
+type promptMark struct {
+	// kind is 'A' (prompt start), 'B' (command start), 'C' (output start) or 'D' (command finished).
+	kind byte
+	// x is the position of the mark in the contents.
+	x int
+	// exitCode is the exit status of the command of 'D'.
+	exitCode string
 }
 
 // newESConverter returns a new escape sequence converter.
@@ -99,6 +111,7 @@ func (es *escapeSequence) parseEscapeSequence(st *parseState) bool {
 			parameter := es.parameter.String()
 			es.parameter.Reset()
 			st.style = oscStyle(st.style, parameter)
+			es.addPromptMark(st, parameter)
 			es.state = ansiText
 			return true
 		default: // Ignore.
@@ -445,6 +458,7 @@ func (es *escapeSequence) parseOSC(st *parseState, mainc rune) {
 		parameter := es.parameter.String()
 		es.parameter.Reset()
 		st.style = oscStyle(st.style, parameter)
+		es.addPromptMark(st, parameter)
 		es.state = ansiText
 		return
 	case 0x1b: // ESC.
@@ -482,6 +496,25 @@ func oscStyle(style tcell.Style, paramStr string) tcell.Style {
 	return style
 }
 
+// addPromptMark records the semantic prompt mark of the OSC 133 escape sequence.
+func (es *escapeSequence) addPromptMark(st *parseState, paramStr string) {
+	params := strings.Split(paramStr, ";")
+	if len(params) < 2 || params[0] != "133" || len(params[1]) != 1 {
+		return
+	}
+	mark := promptMark{kind: params[1][0], x: len(st.lc)}
+	switch mark.kind {
+	case 'A', 'B', 'C':
+	case 'D':
+		if len(params) > 2 {
+			mark.exitCode = params[2]
+		}
+	default:
+		return
+	}
+	es.promptMarks = append(es.promptMarks, mark)
+}
+
 // parseOther parses the other escape sequences.
 func (es *escapeSequence) parseOther(st *parseState, mainc rune) {
 	switch mainc {
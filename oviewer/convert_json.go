@@ -0,0 +1,170 @@
+package oviewer
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// jsonIndent is the indentation of the pretty-printed JSON.
+const jsonIndent = "  "
+
+// jsonStyles is the styles of the JSON tokens.
+type jsonStyles struct {
+	// key is the style of the object key.
+	key tcell.Style
+	// str is the style of the string value.
+	str tcell.Style
+	// number is the style of the number value.
+	number tcell.Style
+	// literal is the style of true, false and null.
+	literal tcell.Style
+}
+
+// newJSONStyles returns the JSON styles of the Style.
+func newJSONStyles(s Style) jsonStyles {
+	return jsonStyles{
+		key:     applyStyle(tcell.StyleDefault, s.JSONKey),
+		str:     applyStyle(tcell.StyleDefault, s.JSONString),
+		number:  applyStyle(tcell.StyleDefault, s.JSONNumber),
+		literal: applyStyle(tcell.StyleDefault, s.JSONLiteral),
+	}
+}
+
+// jsonConverter is a converter that pretty-prints JSON Lines.
+// One logical line is expanded into several display rows,
+// so it is used together with the wrap mode.
+type jsonConverter struct {
+	es          *escapeSequence
+	screenWidth int
+	styles      jsonStyles
+}
+
+// newJSONConverter creates a new jsonConverter.
+// If width is 0 or less, the line is not expanded.
+func newJSONConverter(width int, styles jsonStyles) *jsonConverter {
+	return &jsonConverter{
+		es:          newESConverter(),
+		screenWidth: width,
+		styles:      styles,
+	}
+}
+
+// convert converts the JSON line into indented, colored contents.
+func (c *jsonConverter) convert(st *parseState) bool {
+	if c.es.convert(st) {
+		return true
+	}
+	if st.str != "\n" {
+		return false
+	}
+	st.lc = c.convertJSON(st.lc)
+	return false
+}
+
+// convertJSON returns the pretty-printed contents.
+// Lines that are not JSON objects or arrays are returned unchanged.
+func (c *jsonConverter) convertJSON(src contents) contents {
+	if c.screenWidth <= 0 {
+		return src
+	}
+	str, _ := ContentsToStr(src)
+	str = strings.TrimSpace(str)
+	if !isJSONLine(str) {
+		return src
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(str), "", jsonIndent); err != nil {
+		return src
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+	dst := make(contents, 0, buf.Len())
+	for n, line := range lines {
+		dst = append(dst, jsonLineContents(line, c.styles)...)
+		if n < len(lines)-1 {
+			dst = padToWidth(dst, c.screenWidth)
+		}
+	}
+	return dst
+}
+
+// isJSONLine returns true if the string is a JSON object or array.
+func isJSONLine(str string) bool {
+	if len(str) < 2 {
+		return false
+	}
+	if !(str[0] == '{' && str[len(str)-1] == '}') && !(str[0] == '[' && str[len(str)-1] == ']') {
+		return false
+	}
+	return json.Valid([]byte(str))
+}
+
+// padToWidth pads the contents with spaces to a multiple of the width
+// so that the next contents starts on a new display row.
+func padToWidth(lc contents, width int) contents {
+	rem := len(lc) % width
+	if rem == 0 && len(lc) > 0 {
+		return lc
+	}
+	for range width - rem {
+		lc = append(lc, SpaceContent)
+	}
+	return lc
+}
+
+// jsonLineContents returns the colored contents of one line of indented JSON.
+func jsonLineContents(line string, styles jsonStyles) contents {
+	lc := make(contents, 0, len(line))
+	for len(line) > 0 {
+		token, style := jsonToken(line, styles)
+		for _, c := range RawStrToContents(token, 0) {
+			c.style = style
+			lc = append(lc, c)
+		}
+		line = line[len(token):]
+	}
+	return lc
+}
+
+// jsonToken returns the first token of the line and its style.
+func jsonToken(line string, styles jsonStyles) (string, tcell.Style) {
+	switch ch := line[0]; {
+	case ch == '"':
+		end := jsonStringEnd(line)
+		if strings.HasPrefix(strings.TrimLeft(line[end:], " "), ":") {
+			return line[:end], styles.key
+		}
+		return line[:end], styles.str
+	case ch == '-' || (ch >= '0' && ch <= '9'):
+		return line[:jsonValueEnd(line)], styles.number
+	case ch == 't' || ch == 'f' || ch == 'n':
+		return line[:jsonValueEnd(line)], styles.literal
+	default:
+		return line[:1], tcell.StyleDefault
+	}
+}
+
+// jsonStringEnd returns the end position of the JSON string that starts at the beginning.
+func jsonStringEnd(line string) int {
+	for i := 1; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(line)
+}
+
+// jsonValueEnd returns the end position of the number or literal that starts at the beginning.
+func jsonValueEnd(line string) int {
+	end := strings.IndexAny(line, ",]} ")
+	if end < 0 {
+		return len(line)
+	}
+	return end
+}
@@ -0,0 +1,124 @@
+package oviewer
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v3"
+	"github.com/gdamore/tcell/v3/color"
+)
+
+func TestConvertJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		screenWidth int
+		str         string
+		wantStr     string
+	}{
+		{
+			name:        "empty content",
+			screenWidth: 10,
+			str:         "",
+			wantStr:     "",
+		},
+		{
+			name:        "zero screen width",
+			screenWidth: 0,
+			str:         `{"a":1}`,
+			wantStr:     `{"a":1}`,
+		},
+		{
+			name:        "not JSON",
+			screenWidth: 10,
+			str:         "level=info msg=test",
+			wantStr:     "level=info msg=test",
+		},
+		{
+			name:        "invalid JSON",
+			screenWidth: 10,
+			str:         `{"a":}`,
+			wantStr:     `{"a":}`,
+		},
+		{
+			name:        "object",
+			screenWidth: 10,
+			str:         `{"a":1,"b":"c"}`,
+			wantStr:     "{         " + `  "a": 1, ` + `  "b": "c"` + "}",
+		},
+		{
+			name:        "array",
+			screenWidth: 6,
+			str:         `[true,null]`,
+			wantStr:     "[     " + "  true," + "     " + "  null" + "]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converter := newJSONConverter(tt.screenWidth, newJSONStyles(NewStyle()))
+			result, _ := parseLine(converter, tt.str, 8)
+
+			if result.String() != tt.wantStr {
+				t.Errorf("expected string %q, got %q", tt.wantStr, result.String())
+			}
+		})
+	}
+}
+
+func Test_jsonLineContents(t *testing.T) {
+	t.Parallel()
+	styles := newJSONStyles(NewStyle())
+	tests := []struct {
+		name      string
+		line      string
+		wantStyle []tcell.Style
+	}{
+		{
+			name:      "key and string",
+			line:      `"k": "v"`,
+			wantStyle: []tcell.Style{styles.key, styles.key, styles.key, tcell.StyleDefault, tcell.StyleDefault, styles.str, styles.str, styles.str},
+		},
+		{
+			name:      "number",
+			line:      `-1,`,
+			wantStyle: []tcell.Style{styles.number, styles.number, tcell.StyleDefault},
+		},
+		{
+			name:      "literal",
+			line:      `null`,
+			wantStyle: []tcell.Style{styles.literal, styles.literal, styles.literal, styles.literal},
+		},
+		{
+			name:      "escaped quote",
+			line:      `"a\"b"`,
+			wantStyle: []tcell.Style{styles.str, styles.str, styles.str, styles.str, styles.str, styles.str},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			lc := jsonLineContents(tt.line, styles)
+			if len(lc) != len(tt.wantStyle) {
+				t.Fatalf("jsonLineContents() len = %d, want %d", len(lc), len(tt.wantStyle))
+			}
+			for i, c := range lc {
+				if c.style != tt.wantStyle[i] {
+					t.Errorf("jsonLineContents()[%d] style = %v, want %v", i, c.style, tt.wantStyle[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_newJSONStyles(t *testing.T) {
+	t.Parallel()
+	s := NewStyle()
+	s.JSONKey = OVStyle{Foreground: "red", Underline: true}
+	styles := newJSONStyles(s)
+	lc := jsonLineContents(`"k": 1`, styles)
+	want := tcell.StyleDefault.Foreground(color.Red).Underline(true)
+	if lc[0].style != want {
+		t.Errorf("jsonLineContents() key style = %v, want %v", lc[0].style, want)
+	}
+	if lc[5].style != styles.number {
+		t.Errorf("jsonLineContents() number style = %v, want %v", lc[5].style, styles.number)
+	}
+}
@@ -0,0 +1,610 @@
+package oviewer
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gdamore/tcell/v3"
+	"github.com/gdamore/tcell/v3/color"
+)
+
+// markdownStyles are the styles of the markdown converter.
+type markdownStyles struct {
+	// headings are the styles of the headings by level.
+	// The levels deeper than the styles use the last style.
+	headings []OVStyle
+	// code is the style of the code spans and the fenced code blocks.
+	code tcell.Style
+	// link is the style of the link text.
+	link OVStyle
+	// mark is the style of the bullets, quote bars, rules and table borders.
+	mark tcell.Style
+}
+
+// defaultMarkdownStyles are the styles used to scan the document,
+// where only the text of the contents is needed.
+var defaultMarkdownStyles = newMarkdownStyles(NewStyle())
+
+// newMarkdownStyles returns the markdown styles of the Style.
+func newMarkdownStyles(s Style) markdownStyles {
+	headings := s.MarkdownHeading
+	if len(headings) == 0 {
+		headings = []OVStyle{{}}
+	}
+	return markdownStyles{
+		headings: headings,
+		code:     applyStyle(tcell.StyleDefault, s.MarkdownCode),
+		link:     s.MarkdownLink,
+		mark:     applyStyle(tcell.StyleDefault, s.MarkdownMark),
+	}
+}
+
+// heading returns the style of the heading of the level.
+func (s markdownStyles) heading(level int) OVStyle {
+	return s.headings[min(max(level, 1), len(s.headings))-1]
+}
+
+// markdownListIndent is the indentation added to the lines in a list.
+const markdownListIndent = "  "
+
+var (
+	// markdownATXReg matches the ATX heading such as "## Title".
+	markdownATXReg = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	// markdownSetextReg matches the underline of the setext heading.
+	markdownSetextReg = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	// markdownFenceReg matches the start of the fenced code block.
+	markdownFenceReg = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})[ \t]*([^`]*)$")
+	// markdownRuleReg matches the thematic break.
+	markdownRuleReg = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	// markdownQuoteReg matches the markers of the block quote.
+	markdownQuoteReg = regexp.MustCompile(`^(?: {0,3}>[ \t]?)+`)
+	// markdownListReg matches the marker of the list item.
+	markdownListReg = regexp.MustCompile(`^([ \t]*)([-*+]|\d{1,9}[.)])(?:[ \t]+|$)`)
+	// markdownTableDelimiterReg matches the delimiter row of the table.
+	markdownTableDelimiterReg = regexp.MustCompile(`^[ \t]*\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	// markdownAutolinkReg matches the destination of the autolink such as <https://example.com>.
+	markdownAutolinkReg = regexp.MustCompile(`^(?:https?|ftp|mailto):[^\s<>]*$`)
+)
+
+// markdownKind is the kind of the line in the markdown.
+type markdownKind int
+
+const (
+	markdownText markdownKind = iota
+	markdownHeading
+	markdownSetext
+	markdownFence
+	markdownCode
+	markdownTableHeader
+	markdownTableDelimiter
+	markdownTableRow
+	markdownRule
+)
+
+// markdownLine is the information of a line that depends on the lines around it.
+type markdownLine struct {
+	kind markdownKind
+	// level is the level of the heading.
+	level int
+	// list is true if the line is in a list.
+	list bool
+	// block is the fenced code block of the fence and code lines.
+	block *markdownBlock
+	// table aligns the columns of the table rows.
+	table *align
+}
+
+// markdownBlock is a fenced code block.
+type markdownBlock struct {
+	// width is the maximum width of the code lines.
+	width int
+}
+
+// markdownConverter is a converter that renders markdown.
+// The lines that depend on the other lines, such as code blocks and tables,
+// are rendered with the information scanned from the whole document.
+type markdownConverter struct {
+	es     *escapeSequence
+	line   markdownLine
+	width  int
+	styles markdownStyles
+}
+
+// newMarkdownConverter creates a new markdownConverter for the line.
+// width is the width used for the thematic break.
+func newMarkdownConverter(line markdownLine, width int, styles markdownStyles) *markdownConverter {
+	return &markdownConverter{
+		es:     newESConverter(),
+		line:   line,
+		width:  width,
+		styles: styles,
+	}
+}
+
+// convert renders the markdown line when the end of the line is reached.
+func (c *markdownConverter) convert(st *parseState) bool {
+	if c.es.convert(st) {
+		return true
+	}
+	if st.str != "\n" {
+		return false
+	}
+	st.lc = c.convertMarkdown(st.lc)
+	return false
+}
+
+// convertMarkdown returns the rendered contents of the line.
+func (c *markdownConverter) convertMarkdown(src contents) contents {
+	styles := c.styles
+	switch c.line.kind {
+	case markdownHeading:
+		return markdownHeadingContents(src, c.line.level, styles)
+	case markdownSetext:
+		return withStyle(src, applyStyle(tcell.StyleDefault, styles.heading(c.line.level)))
+	case markdownFence:
+		return markdownFenceContents(src, c.line.block, styles)
+	case markdownCode:
+		return markdownCodeContents(src, c.line.block, styles)
+	case markdownTableHeader, markdownTableRow:
+		return markdownTableContents(src, c.line.table, c.line.kind == markdownTableHeader, styles)
+	case markdownTableDelimiter:
+		return markdownTableDelimiterContents(c.line.table, styles)
+	case markdownRule:
+		width := c.width
+		if width <= 0 {
+			width = len(src)
+		}
+		return styledContents(strings.Repeat("─", width), styles.mark)
+	}
+	return markdownTextContents(src, c.line.list, styles)
+}
+
+// withStyle returns the contents with the style.
+func withStyle(lc contents, style tcell.Style) contents {
+	for i := range lc {
+		lc[i].style = style
+	}
+	return lc
+}
+
+// styledContents returns the contents of the string with the style.
+func styledContents(str string, style tcell.Style) contents {
+	return withStyle(RawStrToContents(str, 0), style)
+}
+
+// markdownHeadingContents returns the heading without the marks.
+// The colors of the heading style are not applied to the code spans.
+func markdownHeadingContents(src contents, level int, styles markdownStyles) contents {
+	str, pos := ContentsToStr(src)
+	match := markdownATXReg.FindStringSubmatchIndex(str)
+	if match != nil {
+		if match[4] < 0 {
+			return nil
+		}
+		src = src[pos.x(match[4]):pos.x(match[5])]
+	}
+	lc := markdownInlineStyles(src.TrimLeft(), styles)
+	style := styles.heading(level)
+	attr := style
+	attr.Foreground, attr.Background = "", ""
+	for i := range lc {
+		if lc[i].style.GetBackground() == color.Default {
+			lc[i].style = applyStyle(lc[i].style, style)
+		} else {
+			lc[i].style = applyStyle(lc[i].style, attr)
+		}
+	}
+	return lc
+}
+
+// markdownFenceContents returns the fence line as the edge of the code block.
+// The info string such as the language name is displayed.
+func markdownFenceContents(src contents, block *markdownBlock, styles markdownStyles) contents {
+	str := strings.TrimSpace(src.String())
+	str = strings.TrimLeft(str, "`~")
+	style := styles.code.Italic(true).Foreground(styles.mark.GetForeground())
+	lc := styledContents(strings.TrimSpace(str), style)
+	return markdownPadBlock(lc, block, styles)
+}
+
+// markdownCodeContents returns the code line as a part of the code block.
+func markdownCodeContents(src contents, block *markdownBlock, styles markdownStyles) contents {
+	return markdownPadBlock(withStyle(src, styles.code), block, styles)
+}
+
+// markdownPadBlock pads the line of the code block to the width of the block.
+func markdownPadBlock(lc contents, block *markdownBlock, styles markdownStyles) contents {
+	width := 0
+	if block != nil {
+		width = block.width
+	}
+	for len(lc) <= width {
+		c := SpaceContent
+		c.style = styles.code
+		lc = append(lc, c)
+	}
+	return lc
+}
+
+// markdownTextContents returns the rendered paragraph, list item or block quote.
+func markdownTextContents(src contents, list bool, styles markdownStyles) contents {
+	dst := make(contents, 0, len(src)+len(markdownListIndent))
+	if list {
+		dst = append(dst, RawStrToContents(markdownListIndent, 0)...)
+	}
+	str, pos := ContentsToStr(src)
+	if match := markdownQuoteReg.FindStringIndex(str); match != nil {
+		num := strings.Count(str[:match[1]], ">")
+		dst = append(dst, styledContents(strings.Repeat("│ ", num), styles.mark)...)
+		src = src[pos.x(match[1]):]
+		str, pos = ContentsToStr(src)
+	}
+	if match := markdownListReg.FindStringSubmatchIndex(str); match != nil {
+		dst = append(dst, src[:pos.x(match[3])]...)
+		marker := str[match[4]:match[5]]
+		if len(marker) == 1 && strings.Contains("-*+", marker) {
+			dst = append(dst, styledContents("•", styles.mark.Bold(true))...)
+		} else {
+			dst = append(dst, styledContents(marker, styles.mark.Bold(true))...)
+		}
+		src = src[pos.x(match[5]):]
+	}
+	return append(dst, markdownInlineStyles(src, styles)...)
+}
+
+// markdownTableCells returns the positions of the cells in the table row.
+// The spaces around the cells are trimmed.
+func markdownTableCells(str string) [][2]int {
+	start, end := 0, len(str)
+	for start < end && (str[start] == ' ' || str[start] == '\t') {
+		start++
+	}
+	for end > start && (str[end-1] == ' ' || str[end-1] == '\t') {
+		end--
+	}
+	if start < end && str[start] == '|' {
+		start++
+	}
+	if end > start && str[end-1] == '|' && (end-2 < start || str[end-2] != '\\') {
+		end--
+	}
+	var cells [][2]int
+	cellStart := start
+	code := false
+	for i := start; i <= end; i++ {
+		if i < end {
+			switch str[i] {
+			case '\\':
+				i++
+				continue
+			case '`':
+				code = !code
+				continue
+			case '|':
+				if code {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		s, e := cellStart, i
+		for s < e && (str[s] == ' ' || str[s] == '\t') {
+			s++
+		}
+		for e > s && (str[e-1] == ' ' || str[e-1] == '\t') {
+			e--
+		}
+		cells = append(cells, [2]int{s, e})
+		cellStart = i + 1
+	}
+	return cells
+}
+
+// markdownTableCellContents returns the rendered contents of the cells.
+func markdownTableCellContents(src contents, styles markdownStyles) []contents {
+	str, pos := ContentsToStr(src)
+	cells := markdownTableCells(str)
+	lcs := make([]contents, 0, len(cells))
+	for _, cell := range cells {
+		lcs = append(lcs, markdownInlineStyles(src[pos.x(cell[0]):pos.x(cell[1])], styles))
+	}
+	return lcs
+}
+
+// markdownTableContents returns the table row with the columns aligned.
+func markdownTableContents(src contents, table *align, header bool, styles markdownStyles) contents {
+	if table == nil {
+		return markdownInlineStyles(src, styles)
+	}
+	cells := markdownTableCellContents(src, styles)
+	border := styledContents("|", styles.mark)
+	dst := make(contents, 0, len(src))
+	for i := range max(len(cells), len(table.maxWidths)) {
+		var cell contents
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		if header {
+			for j := range cell {
+				cell[j].style = cell[j].style.Bold(true)
+			}
+		}
+		dst = append(dst, border...)
+		dst = append(dst, SpaceContent)
+		dst = table.appendColumn(dst, i, cell)
+		dst = append(dst, SpaceContent)
+	}
+	return append(dst, border...)
+}
+
+// markdownTableDelimiterContents returns the delimiter row under the table header.
+func markdownTableDelimiterContents(table *align, styles markdownStyles) contents {
+	if table == nil {
+		return nil
+	}
+	var b strings.Builder
+	for _, width := range table.maxWidths {
+		b.WriteString("|")
+		b.WriteString(strings.Repeat("-", width+2))
+	}
+	b.WriteString("|")
+	return styledContents(b.String(), styles.mark)
+}
+
+// styleFunc modifies the style.
+type styleFunc func(tcell.Style) tcell.Style
+
+// addStyle returns the styleFunc that applies fn after add.
+func addStyle(fn styleFunc, add styleFunc) styleFunc {
+	return func(s tcell.Style) tcell.Style {
+		s = add(s)
+		if fn != nil {
+			s = fn(s)
+		}
+		return s
+	}
+}
+
+// markdownInliner renders the inline elements of markdown.
+type markdownInliner struct {
+	src    contents
+	str    string
+	pos    widthPos
+	dst    contents
+	styles markdownStyles
+}
+
+// markdownInline returns the contents with the inline elements rendered
+// with the default styles.
+func markdownInline(src contents) contents {
+	return markdownInlineStyles(src, defaultMarkdownStyles)
+}
+
+// markdownInlineStyles returns the contents with the inline elements rendered.
+// Emphasis, code spans and links are styled and their marks are removed.
+func markdownInlineStyles(src contents, styles markdownStyles) contents {
+	str, pos := ContentsToStr(src)
+	r := &markdownInliner{
+		src:    src,
+		str:    str,
+		pos:    pos,
+		dst:    make(contents, 0, len(src)),
+		styles: styles,
+	}
+	r.render(0, len(str), nil)
+	return r.dst
+}
+
+// emit appends the contents of the range of the string with the style.
+func (r *markdownInliner) emit(start int, end int, fn styleFunc) {
+	for _, c := range r.src[r.pos.x(start):r.pos.x(end)] {
+		if fn != nil {
+			c.style = fn(c.style)
+		}
+		r.dst = append(r.dst, c)
+	}
+}
+
+// render renders the range of the string.
+func (r *markdownInliner) render(start int, end int, fn styleFunc) {
+	str := r.str
+	plain := start
+	for i := start; i < end; {
+		next := -1
+		switch str[i] {
+		case '\\':
+			if i+1 < end && isASCIIPunct(str[i+1]) {
+				r.emit(plain, i, fn)
+				plain = i + 1
+				i += 2
+				continue
+			}
+		case '`':
+			next = r.codeSpan(i, end, fn, plain)
+			if next < 0 {
+				i += runLength(str[i:end], '`')
+				continue
+			}
+		case '!':
+			if i+1 < end && str[i+1] == '[' {
+				next = r.link(i, i+1, end, fn, plain)
+			}
+		case '[':
+			next = r.link(i, i, end, fn, plain)
+		case '<':
+			next = r.autolink(i, end, fn, plain)
+		case '*', '_', '~':
+			next = r.emphasis(i, end, fn, plain)
+			if next < 0 {
+				i += runLength(str[i:end], str[i])
+				continue
+			}
+		}
+		if next < 0 {
+			i++
+			continue
+		}
+		i, plain = next, next
+	}
+	r.emit(plain, end, fn)
+}
+
+// codeSpan renders the code span at i and returns the position after it.
+// It returns -1 if there is no closing backticks.
+func (r *markdownInliner) codeSpan(i int, end int, fn styleFunc, plain int) int {
+	str := r.str
+	n := runLength(str[i:end], '`')
+	for j := i + n; j < end; {
+		if str[j] != '`' {
+			j++
+			continue
+		}
+		m := runLength(str[j:end], '`')
+		if m != n {
+			j += m
+			continue
+		}
+		r.emit(plain, i, fn)
+		s, e := i+n, j
+		if e-s >= 2 && str[s] == ' ' && str[e-1] == ' ' && strings.TrimSpace(str[s:e]) != "" {
+			s, e = s+1, e-1
+		}
+		r.emit(s, e, addStyle(fn, func(tcell.Style) tcell.Style { return r.styles.code }))
+		return j + n
+	}
+	return -1
+}
+
+// link renders the link or the image at i and returns the position after it.
+// The text of the link is rendered as an OSC 8 hyperlink.
+// bracket is the position of '['. It returns -1 if it is not a link.
+func (r *markdownInliner) link(i int, bracket int, end int, fn styleFunc, plain int) int {
+	str := r.str
+	textEnd := matchingBracket(str[:end], bracket, '[', ']')
+	if textEnd < 0 || textEnd+1 >= end || str[textEnd+1] != '(' {
+		return -1
+	}
+	destEnd := matchingBracket(str[:end], textEnd+1, '(', ')')
+	if destEnd < 0 {
+		return -1
+	}
+	dest := strings.TrimSpace(str[textEnd+2 : destEnd])
+	if fields := strings.Fields(dest); len(fields) > 0 {
+		dest = fields[0]
+	}
+	dest = strings.TrimSuffix(strings.TrimPrefix(dest, "<"), ">")
+	r.emit(plain, i, fn)
+	linkStyle := addStyle(fn, func(s tcell.Style) tcell.Style {
+		return applyStyle(s, r.styles.link).Url(dest)
+	})
+	r.render(bracket+1, textEnd, linkStyle)
+	return destEnd + 1
+}
+
+// autolink renders the autolink at i and returns the position after it.
+// It returns -1 if it is not an autolink.
+func (r *markdownInliner) autolink(i int, end int, fn styleFunc, plain int) int {
+	str := r.str
+	closeAt := strings.IndexByte(str[i:end], '>')
+	if closeAt < 0 {
+		return -1
+	}
+	url := str[i+1 : i+closeAt]
+	if !markdownAutolinkReg.MatchString(url) {
+		return -1
+	}
+	r.emit(plain, i, fn)
+	r.emit(i+1, i+closeAt, addStyle(fn, func(s tcell.Style) tcell.Style {
+		return applyStyle(s, r.styles.link).Url(url)
+	}))
+	return i + closeAt + 1
+}
+
+// emphasis renders the emphasis, the strong emphasis or the strikethrough at i
+// and returns the position after it.
+// It returns -1 if there is no closing delimiter.
+func (r *markdownInliner) emphasis(i int, end int, fn styleFunc, plain int) int {
+	str := r.str
+	ch := str[i]
+	n := min(runLength(str[i:end], ch), 3)
+	if ch == '~' && n != 2 {
+		return -1
+	}
+	if i+n >= end || isSpaceByte(str[i+n]) {
+		return -1
+	}
+	if ch == '_' && i > 0 && isWordByte(str[i-1]) {
+		return -1
+	}
+	for j := i + n; j < end; {
+		if str[j] != ch {
+			j++
+			continue
+		}
+		m := runLength(str[j:end], ch)
+		if m < n || isSpaceByte(str[j-1]) || (ch == '_' && j+m < len(str) && isWordByte(str[j+m])) {
+			j += m
+			continue
+		}
+		r.emit(plain, i, fn)
+		var add styleFunc
+		switch {
+		case ch == '~':
+			add = func(s tcell.Style) tcell.Style { return s.StrikeThrough(true) }
+		case n == 1:
+			add = func(s tcell.Style) tcell.Style { return s.Italic(true) }
+		case n == 2:
+			add = func(s tcell.Style) tcell.Style { return s.Bold(true) }
+		default:
+			add = func(s tcell.Style) tcell.Style { return s.Bold(true).Italic(true) }
+		}
+		r.render(i+n, j, addStyle(fn, add))
+		return j + n
+	}
+	return -1
+}
+
+// runLength returns the number of the repeated ch at the beginning of str.
+func runLength(str string, ch byte) int {
+	n := 0
+	for n < len(str) && str[n] == ch {
+		n++
+	}
+	return n
+}
+
+// matchingBracket returns the position of the close bracket matching the open bracket at start.
+// It returns -1 if there is no matching bracket.
+func matchingBracket(str string, start int, open byte, closeCh byte) int {
+	depth := 0
+	for i := start; i < len(str); i++ {
+		switch str[i] {
+		case '\\':
+			i++
+		case open:
+			depth++
+		case closeCh:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// isASCIIPunct returns true if the byte is an ASCII punctuation character.
+func isASCIIPunct(b byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", b) >= 0
+}
+
+// isSpaceByte returns true if the byte is a space or a tab.
+func isSpaceByte(b byte) bool {
+	return b == ' ' || b == '\t'
+}
+
+// isWordByte returns true if the byte is a part of a word.
+func isWordByte(b byte) bool {
+	return b == '_' || b >= 0x80 || ('0' <= b && b <= '9') || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
@@ -0,0 +1,205 @@
+package oviewer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v3/color"
+)
+
+func Test_markdownInline(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		str        string
+		want       string
+		wantBold   string
+		wantItalic string
+		wantURL    string
+	}{
+		{
+			name: "plain",
+			str:  "plain text",
+			want: "plain text",
+		},
+		{
+			name:     "bold",
+			str:      "a **b** c",
+			want:     "a b c",
+			wantBold: "b",
+		},
+		{
+			name:       "italic",
+			str:        "a _b_ *c*",
+			want:       "a b c",
+			wantItalic: "bc",
+		},
+		{
+			name:       "bold italic",
+			str:        "***b***",
+			want:       "b",
+			wantBold:   "b",
+			wantItalic: "b",
+		},
+		{
+			name: "code span",
+			str:  "`**a**`",
+			want: "**a**",
+		},
+		{
+			name: "escape",
+			str:  `\*a\*`,
+			want: "*a*",
+		},
+		{
+			name: "intraword underscore",
+			str:  "snake_case_name",
+			want: "snake_case_name",
+		},
+		{
+			name:    "link",
+			str:     "[ov](https://github.com/noborus/ov)",
+			want:    "ov",
+			wantURL: "https://github.com/noborus/ov",
+		},
+		{
+			name:    "autolink",
+			str:     "<https://example.com>",
+			want:    "https://example.com",
+			wantURL: "https://example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := markdownInline(StrToContents(tt.str, 8))
+			if got.String() != tt.want {
+				t.Errorf("markdownInline() = %q, want %q", got.String(), tt.want)
+			}
+			var bold, italic, url string
+			for _, c := range got {
+				if c.style.HasBold() {
+					bold += c.str
+				}
+				if c.style.HasItalic() {
+					italic += c.str
+				}
+				if _, u := c.style.GetUrl(); u != "" {
+					url = u
+				}
+			}
+			if bold != tt.wantBold {
+				t.Errorf("markdownInline() bold = %q, want %q", bold, tt.wantBold)
+			}
+			if italic != tt.wantItalic {
+				t.Errorf("markdownInline() italic = %q, want %q", italic, tt.wantItalic)
+			}
+			if url != tt.wantURL {
+				t.Errorf("markdownInline() url = %q, want %q", url, tt.wantURL)
+			}
+		})
+	}
+}
+
+func Test_markdownTableCells(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		str  string
+		want [][2]int
+	}{
+		{
+			name: "outer pipes",
+			str:  "| a | b |",
+			want: [][2]int{{2, 3}, {6, 7}},
+		},
+		{
+			name: "no outer pipes",
+			str:  "a|b",
+			want: [][2]int{{0, 1}, {2, 3}},
+		},
+		{
+			name: "escaped pipe",
+			str:  `| a\|b | c |`,
+			want: [][2]int{{2, 6}, {9, 10}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := markdownTableCells(tt.str); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("markdownTableCells() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertMarkdown(t *testing.T) {
+	t.Parallel()
+	block := &markdownBlock{width: 6}
+	tests := []struct {
+		name string
+		line markdownLine
+		str  string
+		want string
+	}{
+		{
+			name: "heading",
+			line: markdownLine{kind: markdownHeading, level: 2},
+			str:  "## Install ##",
+			want: "Install",
+		},
+		{
+			name: "code",
+			line: markdownLine{kind: markdownCode, block: block},
+			str:  "*a*",
+			want: "*a*    ",
+		},
+		{
+			name: "list",
+			line: markdownLine{list: true},
+			str:  "- item",
+			want: "  • item",
+		},
+		{
+			name: "quote",
+			line: markdownLine{},
+			str:  "> quote",
+			want: "│ quote",
+		},
+		{
+			name: "rule",
+			line: markdownLine{kind: markdownRule},
+			str:  "---",
+			want: "─────",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			converter := newMarkdownConverter(tt.line, 5, defaultMarkdownStyles)
+			got, _ := parseLine(converter, tt.str, 8)
+			if got.String() != tt.want {
+				t.Errorf("convertMarkdown() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertMarkdown_styles(t *testing.T) {
+	t.Parallel()
+	s := NewStyle()
+	s.MarkdownHeading = []OVStyle{{Foreground: "red"}}
+	s.MarkdownLink = OVStyle{Foreground: "green"}
+	styles := newMarkdownStyles(s)
+
+	converter := newMarkdownConverter(markdownLine{kind: markdownHeading, level: 3}, 5, styles)
+	got, _ := parseLine(converter, "### Title", 8)
+	if fg := got[0].style.GetForeground(); fg != color.Red {
+		t.Errorf("heading foreground = %v, want %v", fg, color.Red)
+	}
+	lc := markdownInlineStyles(StrToContents("[link](https://example.com)", 8), styles)
+	if fg := lc[0].style.GetForeground(); fg != color.Green {
+		t.Errorf("link foreground = %v, want %v", fg, color.Green)
+	}
+}
@@ -0,0 +1,99 @@
+package oviewer
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"unicode/utf8"
+)
+
+// csvRecords toggles between the CSV records document and the original document.
+func (root *Root) csvRecords(ctx context.Context) {
+	if root.toggleColumnDocument(ctx) {
+		return
+	}
+	root.csvDocument(ctx)
+}
+
+// csvDocument creates a new document with the CSV (RFC 4180) records of the current document.
+// Quoted values are unquoted, and records spanning multiple lines are joined into one row.
+func (root *Root) csvDocument(ctx context.Context) {
+	m := root.Doc
+	comma, err := csvComma(m.ColumnDelimiter, m.ColumnDelimiterReg)
+	if err != nil {
+		root.setMessage(err.Error())
+		return
+	}
+	render, w, err := newColumnDocument(m, "csv")
+	if err != nil {
+		log.Printf("failed to read CSV: %v\n", err)
+		return
+	}
+	render.SkipLines = m.SkipLines
+	render.Header = m.Header
+	root.insertDocument(ctx, root.CurrentDoc, render)
+
+	go func() {
+		defer closeFile(w)
+		m.WaitEOFWithTimeout(root.Config.ReadWaitTime)
+		m.csvWriter(ctx, w, render, comma)
+	}()
+}
+
+// csvComma returns the field delimiter for CSV.
+// The delimiter must be a single character other than a quote or a newline.
+func csvComma(delimiter string, delimiterReg *regexp.Regexp) (rune, error) {
+	if delimiterReg != nil || utf8.RuneCountInString(delimiter) != 1 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCSVDelimiter, delimiter)
+	}
+	comma, _ := utf8.DecodeRuneInString(delimiter)
+	if comma == '"' || comma == '\r' || comma == '\n' || comma == utf8.RuneError {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCSVDelimiter, delimiter)
+	}
+	return comma, nil
+}
+
+// csvWriter writes the unquoted CSV records to w.
+// Each record is mapped to the line where it starts.
+func (m *Document) csvWriter(ctx context.Context, w io.Writer, render *Document, comma rune) {
+	start := m.BufStartNum()
+	pr, pw := io.Pipe()
+	defer closeFile(pr)
+	go func() {
+		err := m.eachLine(ctx, start, func(_ int, line []byte) bool {
+			if _, err := pw.Write(line); err != nil {
+				return false
+			}
+			_, err := pw.Write([]byte("\n"))
+			return err == nil
+		})
+		pw.CloseWithError(err)
+	}()
+
+	reader := csv.NewReader(pr)
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	for renderLN := 0; ; {
+		record, err := reader.Read()
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				log.Printf("CSV: %v\n", err)
+				continue
+			}
+			if !errors.Is(err, io.EOF) {
+				log.Printf("failed to read CSV: %v\n", err)
+			}
+			return
+		}
+		line, _ := reader.FieldPos(0)
+		render.lineNumMap.Store(renderLN, start+line-1)
+		writeLine(w, []byte(joinColumns(record)))
+		renderLN++
+	}
+}
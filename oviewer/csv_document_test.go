@@ -0,0 +1,99 @@
+package oviewer
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+func Test_csvComma(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		delimiter    string
+		delimiterReg *regexp.Regexp
+		want         rune
+		wantErr      bool
+	}{
+		{name: "comma", delimiter: ",", want: ','},
+		{name: "tab", delimiter: "\t", want: '\t'},
+		{name: "multibyte", delimiter: "│", want: '│'},
+		{name: "empty", delimiter: "", wantErr: true},
+		{name: "multiple", delimiter: "||", wantErr: true},
+		{name: "quote", delimiter: `"`, wantErr: true},
+		{name: "regexp", delimiter: `/\s+/`, delimiterReg: regexp.MustCompile(`\s+`), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := csvComma(tt.delimiter, tt.delimiterReg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("csvComma() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidCSVDelimiter) {
+				t.Errorf("csvComma() error = %v, want %v", err, ErrInvalidCSVDelimiter)
+			}
+			if got != tt.want {
+				t.Errorf("csvComma() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoot_csvDocument(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "quoted.csv"))
+	root.Doc.WaitEOF()
+	root.Doc.ColumnDelimiter = ","
+	root.Doc.Header = 1
+	root.csvDocument(context.Background())
+	if root.DocumentLen() != 2 {
+		t.Fatalf("csvDocument() documents = %v, want 2", root.DocumentLen())
+	}
+	doc := root.DocList[len(root.DocList)-1]
+	doc.WaitEOF()
+	if doc.Header != 1 || doc.ColumnDelimiter != "\t" {
+		t.Errorf("csvDocument() header = %v, delimiter = %q", doc.Header, doc.ColumnDelimiter)
+	}
+	wantLines := []string{
+		"id\tcity\tnote",
+		"1\tTokyo, Japan\tplain",
+		"2\tOsaka\tmulti\\nline",
+		"3\tsay \"hi\"\tx",
+	}
+	for lN, want := range wantLines {
+		line, err := doc.Line(lN)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(line) != want {
+			t.Errorf("csvDocument() line %d = %q, want %q", lN, line, want)
+		}
+	}
+	wantMap := map[int]int{0: 0, 1: 1, 2: 2, 3: 4}
+	for renderLN, want := range wantMap {
+		if n, ok := doc.lineNumMap.LoadForward(renderLN); !ok || n != want {
+			t.Errorf("csvDocument() lineNumMap[%d] = %v, want %v", renderLN, n, want)
+		}
+	}
+}
+
+func TestRoot_csvDocumentInvalidDelimiter(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "quoted.csv"))
+	root.Doc.setDelimiter(`/,+/`)
+	root.csvDocument(context.Background())
+	if root.DocumentLen() != 1 {
+		t.Errorf("csvDocument() documents = %v, want 1", root.DocumentLen())
+	}
+}
@@ -0,0 +1,325 @@
+package oviewer
+
+import (
+	"context"
+	"log"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// The name of the format that can be detected.
+// A view mode with the same name takes precedence over the default settings.
+const (
+	formatPlain  string = "plain"
+	formatCSV    string = "csv"
+	formatTSV    string = "tsv"
+	formatPSQL   string = "psql"
+	formatMySQL  string = "mysql"
+	formatJSONL  string = "jsonl"
+	formatLogfmt string = "logfmt"
+	formatDiff   string = "diff"
+	formatMan    string = "man"
+)
+
+// detectSampleLines is the number of lines used to detect the format.
+const detectSampleLines = 100
+
+var (
+	// psqlBorderReg matches the border under the psql header.
+	psqlBorderReg = regexp.MustCompile(`^-+(\+-+)*$`)
+	// mysqlBorderReg matches the border of the mysql table.
+	mysqlBorderReg = regexp.MustCompile(`^\+(-+\+)+$`)
+	// manHeaderReg matches the first line of the man page.
+	manHeaderReg = regexp.MustCompile(`^(\S+)\(\w+\)\s+.*\s+(\S+)\(\w+\)$`)
+)
+
+// detectFormat guesses the format from the sample lines.
+func detectFormat(lines []string) string {
+	lines = nonEmptyLines(lines)
+	if len(lines) == 0 {
+		return formatPlain
+	}
+	switch {
+	case isManFormat(lines):
+		return formatMan
+	case isDiffFormat(lines):
+		return formatDiff
+	}
+
+	plain := make([]string, len(lines))
+	for i, line := range lines {
+		plain[i] = stripEscapeSequenceString(line)
+	}
+	switch {
+	case isMySQLFormat(plain):
+		return formatMySQL
+	case isPSQLFormat(plain):
+		return formatPSQL
+	case mostLines(plain, isJSONLine):
+		return formatJSONL
+	case mostLines(plain, isLogfmtLine):
+		return formatLogfmt
+	case isDelimitedFormat(plain, "\t"):
+		return formatTSV
+	case isDelimitedFormat(plain, ","):
+		return formatCSV
+	}
+	return formatPlain
+}
+
+// nonEmptyLines returns the lines that are not blank.
+func nonEmptyLines(lines []string) []string {
+	list := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		list = append(list, line)
+	}
+	return list
+}
+
+// mostLines returns true if fn returns true for 80% or more of the lines.
+func mostLines(lines []string, fn func(string) bool) bool {
+	count := 0
+	for _, line := range lines {
+		if fn(line) {
+			count++
+		}
+	}
+	return count > 0 && count*5 >= len(lines)*4
+}
+
+// isManFormat returns true if the lines look like a formatted man page.
+func isManFormat(lines []string) bool {
+	if manHeaderReg.MatchString(strings.TrimSpace(lines[0])) {
+		return true
+	}
+	for _, line := range lines {
+		// Overstrike for bold and underline.
+		if strings.Contains(line, "\b") {
+			return true
+		}
+	}
+	return false
+}
+
+// isDiffFormat returns true if the lines look like a unified diff.
+func isDiffFormat(lines []string) bool {
+	if strings.HasPrefix(lines[0], "diff ") {
+		return true
+	}
+	if len(lines) < 2 {
+		return false
+	}
+	return strings.HasPrefix(lines[0], "--- ") && strings.HasPrefix(lines[1], "+++ ")
+}
+
+// isMySQLFormat returns true if the lines look like the output of mysql.
+func isMySQLFormat(lines []string) bool {
+	if len(lines) < 3 {
+		return false
+	}
+	return mysqlBorderReg.MatchString(lines[0]) && strings.HasPrefix(lines[1], "|")
+}
+
+// isPSQLFormat returns true if the lines look like the output of psql.
+func isPSQLFormat(lines []string) bool {
+	if len(lines) < 2 {
+		return false
+	}
+	return strings.Contains(lines[0], "|") && psqlBorderReg.MatchString(strings.TrimSpace(lines[1]))
+}
+
+// isLogfmtLine returns true if the line has two or more key=value pairs.
+func isLogfmtLine(line string) bool {
+	return len(parseLogfmt(line)) >= 2
+}
+
+// isDelimitedFormat returns true if most lines have the same number of delimiters as the first line.
+func isDelimitedFormat(lines []string, delimiter string) bool {
+	if len(lines) < 2 {
+		return false
+	}
+	num := len(delimiterIndex(lines[0], delimiter))
+	if num == 0 {
+		return false
+	}
+	return mostLines(lines, func(line string) bool {
+		return len(delimiterIndex(line, delimiter)) == num
+	})
+}
+
+// formatSettings returns the settings for the format.
+// If there is a view mode with the same name as the format, it is used.
+func (root *Root) formatSettings(base RunTimeSettings, format string) RunTimeSettings {
+	if mode, ok := root.Config.Mode[format]; ok {
+		return updateRunTimeSettings(base, mode)
+	}
+	return updateRunTimeSettings(base, defaultFormatGeneral(format))
+}
+
+// defaultFormatGeneral returns the default settings for the format.
+func defaultFormatGeneral(format string) General {
+	g := General{}
+	switch format {
+	case formatCSV, formatTSV:
+		g.SetColumnMode(true)
+		g.SetColumnDelimiter(",")
+		if format == formatTSV {
+			g.SetColumnDelimiter("\t")
+		}
+		g.SetHeader(1)
+		g.SetAlign(true)
+	case formatPSQL, formatMySQL:
+		g.SetColumnMode(true)
+		g.SetColumnDelimiter("|")
+		g.SetHeader(2)
+		if format == formatMySQL {
+			g.SetHeader(3)
+		}
+		g.SetAlternateRows(true)
+	case formatJSONL:
+		g.SetConverter(convJSON)
+		g.SetWrapMode(true)
+	case formatDiff:
+		g.SetDiffMode(true)
+		g.SetSectionHeader(true)
+	case formatMan:
+		g.SetManMode(true)
+		g.SetSectionHeader(true)
+	}
+	return g
+}
+
+// sampleLines returns the first lines of the document.
+func (m *Document) sampleLines(ctx context.Context, num int) []string {
+	lines := make([]string, 0, num)
+	err := m.eachLine(ctx, 0, func(_ int, line []byte) bool {
+		lines = append(lines, string(line))
+		return len(lines) < num
+	})
+	if err != nil {
+		log.Printf("failed to read sample lines: %v\n", err)
+	}
+	return lines
+}
+
+// sampleFormat returns the format detected from the first lines of the document.
+func (m *Document) sampleFormat(ctx context.Context) string {
+	return detectFormat(m.sampleLines(ctx, detectSampleLines))
+}
+
+// applyDetectedFormat applies the settings of the format detected from the document.
+// The settings before detection are saved so that they can be restored.
+// The format that changes no settings is not applied.
+func (root *Root) applyDetectedFormat(ctx context.Context, m *Document, format string) {
+	if m.detectedFormat != "" {
+		return
+	}
+	m.detectedFormat = format
+	settings := m.RunTimeSettings
+	m.undetectedSettings = &settings
+	m.detectedSettings = root.formatSettings(settings, format)
+	if len(changedSettingFields(settings, m.detectedSettings)) == 0 {
+		if m == root.Doc {
+			root.setMessageLogf("Detected %s: no settings to apply", format)
+		}
+		return
+	}
+	m.formatApplied = true
+	m.RunTimeSettings = m.detectedSettings
+	m.regexpCompile()
+	m.ClearCache()
+	if m != root.Doc {
+		return
+	}
+	root.ViewSync(ctx)
+	root.setMessageLogf("Detected %s", format)
+}
+
+// toggleDetectFormat toggles between the settings of the detected format and the original settings.
+// Only the settings changed by the detected format are toggled,
+// so that the settings changed after detection are kept.
+// If the format has not been detected, it is detected in the background.
+func (root *Root) toggleDetectFormat(ctx context.Context) {
+	m := root.Doc
+	if m.undetectedSettings == nil {
+		root.setMessage("detecting format...")
+		go root.sendDetectFormat(m)
+		return
+	}
+	fields := changedSettingFields(*m.undetectedSettings, m.detectedSettings)
+	if len(fields) == 0 {
+		root.setMessagef("Detected %s: no settings to apply", m.detectedFormat)
+		return
+	}
+	m.formatApplied = !m.formatApplied
+	src := *m.undetectedSettings
+	if m.formatApplied {
+		src = m.detectedSettings
+	}
+	copySettingFields(&m.RunTimeSettings, src, fields)
+	m.regexpCompile()
+	m.ClearCache()
+	root.ViewSync(ctx)
+	if m.formatApplied {
+		root.setMessagef("Detected %s", m.detectedFormat)
+		return
+	}
+	root.setMessagef("Undo detected %s", m.detectedFormat)
+}
+
+// changedSettingFields returns the indexes of the fields that differ between the settings.
+func changedSettingFields(a RunTimeSettings, b RunTimeSettings) []int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	var fields []int
+	for i := range va.NumField() {
+		if !reflect.DeepEqual(va.Field(i).Interface(), vb.Field(i).Interface()) {
+			fields = append(fields, i)
+		}
+	}
+	return fields
+}
+
+// copySettingFields copies the fields of the indexes from src to dst.
+func copySettingFields(dst *RunTimeSettings, src RunTimeSettings, fields []int) {
+	vd, vs := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src)
+	for _, i := range fields {
+		vd.Field(i).Set(vs.Field(i))
+	}
+}
+
+// eventDetectFormat represents the event to apply the format detected from the document.
+type eventDetectFormat struct {
+	m      *Document
+	format string
+	tcell.EventTime
+}
+
+// monitorFormat detects the format of each document if AutoDetect is enabled.
+// The documents added later are detected by addDocument.
+func (root *Root) monitorFormat() {
+	if !root.Config.AutoDetect {
+		return
+	}
+	for _, m := range root.DocList {
+		go root.sendDetectFormat(m)
+	}
+}
+
+// sendDetectFormat detects the format after the first chunk is read
+// and fires the eventDetectFormat event.
+// The first lines are read in the goroutine, not in the event loop.
+func (root *Root) sendDetectFormat(m *Document) {
+	m.WaitEOFWithTimeout(root.Config.ReadWaitTime)
+	ev := &eventDetectFormat{}
+	ev.m = m
+	ev.format = m.sampleFormat(context.Background())
+	ev.SetEventNow()
+	root.postEvent(ev)
+}
@@ -0,0 +1,184 @@
+package oviewer
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+func Test_detectFormat(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		lines []string
+		want  string
+	}{
+		{
+			name:  "empty",
+			lines: []string{"", " "},
+			want:  formatPlain,
+		},
+		{
+			name:  "plain",
+			lines: []string{"hello world", "this is a test"},
+			want:  formatPlain,
+		},
+		{
+			name:  "csv",
+			lines: []string{"id,name,email", "1,foo,foo@example.com", `2,"bar, baz",bar@example.com`},
+			want:  formatCSV,
+		},
+		{
+			name:  "tsv",
+			lines: []string{"id\tname", "1\tfoo", "2\tbar"},
+			want:  formatTSV,
+		},
+		{
+			name:  "psql",
+			lines: []string{" id | name ", "----+------", "  1 | foo", "(1 row)"},
+			want:  formatPSQL,
+		},
+		{
+			name:  "mysql",
+			lines: []string{"+----+------+", "| id | name |", "+----+------+", "|  1 | foo  |", "+----+------+"},
+			want:  formatMySQL,
+		},
+		{
+			name:  "jsonl",
+			lines: []string{`{"level":"info","msg":"start"}`, `{"level":"warn","msg":"slow"}`},
+			want:  formatJSONL,
+		},
+		{
+			name:  "logfmt",
+			lines: []string{`level=info msg="server started"`, "level=warn msg=slow"},
+			want:  formatLogfmt,
+		},
+		{
+			name:  "diff",
+			lines: []string{"diff --git a/a.go b/a.go", "index 1..2", "--- a/a.go", "+++ b/a.go"},
+			want:  formatDiff,
+		},
+		{
+			name:  "unified diff",
+			lines: []string{"--- a.txt", "+++ b.txt", "@@ -1 +1 @@"},
+			want:  formatDiff,
+		},
+		{
+			name:  "man",
+			lines: []string{"LS(1)                 User Commands                 LS(1)", "", "NAME", "       ls - list directory contents"},
+			want:  formatMan,
+		},
+		{
+			name:  "man overstrike",
+			lines: []string{"N\bNA\bAM\bME\bE", "       ls - list directory contents"},
+			want:  formatMan,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := detectFormat(tt.lines); got != tt.want {
+				t.Errorf("detectFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoot_toggleDetectFormat(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	tests := []struct {
+		name       string
+		fileName   string
+		mode       map[string]General
+		wantFormat string
+		wantDelm   string
+		wantHeader int
+	}{
+		{
+			name:       "csv default",
+			fileName:   "MOCK_DATA.csv",
+			wantFormat: formatCSV,
+			wantDelm:   ",",
+			wantHeader: 1,
+		},
+		{
+			name:     "csv view mode",
+			fileName: "MOCK_DATA.csv",
+			mode: map[string]General{
+				formatCSV: func() General {
+					g := General{}
+					g.SetColumnMode(true)
+					g.SetColumnDelimiter(",")
+					g.SetHeader(2)
+					return g
+				}(),
+			},
+			wantFormat: formatCSV,
+			wantDelm:   ",",
+			wantHeader: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := rootFileReadHelper(t, filepath.Join(testdata, tt.fileName))
+			root.Config.Mode = tt.mode
+			root.Doc.WaitEOF()
+			ctx := context.Background()
+			m := root.Doc
+			root.applyDetectedFormat(ctx, m, m.sampleFormat(ctx))
+			if m.detectedFormat != tt.wantFormat {
+				t.Errorf("applyDetectedFormat() format = %v, want %v", m.detectedFormat, tt.wantFormat)
+			}
+			if got := root.StrLeftStatus(false); !strings.Contains(got, "("+tt.wantFormat+")") {
+				t.Errorf("StrLeftStatus() = %q, want the detected format", got)
+			}
+			if !m.ColumnMode || m.ColumnDelimiter != tt.wantDelm || m.Header != tt.wantHeader {
+				t.Errorf("toggleDetectFormat() column mode = %v, delimiter = %q, header = %v", m.ColumnMode, m.ColumnDelimiter, m.Header)
+			}
+			// The settings changed by the user after detection are kept.
+			m.TabWidth = 3
+			root.toggleDetectFormat(ctx)
+			if m.ColumnMode || m.Header != 0 {
+				t.Errorf("toggleDetectFormat() undo column mode = %v, header = %v", m.ColumnMode, m.Header)
+			}
+			if got := root.StrLeftStatus(false); strings.Contains(got, "("+tt.wantFormat+")") {
+				t.Errorf("StrLeftStatus() = %q, want no detected format after undo", got)
+			}
+			root.toggleDetectFormat(ctx)
+			if !m.ColumnMode || m.Header != tt.wantHeader {
+				t.Errorf("toggleDetectFormat() redo column mode = %v, header = %v", m.ColumnMode, m.Header)
+			}
+			if m.TabWidth != 3 {
+				t.Errorf("toggleDetectFormat() tab width = %v, want 3", m.TabWidth)
+			}
+		})
+	}
+}
+
+func TestRoot_applyDetectedFormat_noSettings(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "logfmt.log"))
+	root.Doc.WaitEOF()
+	ctx := context.Background()
+	m := root.Doc
+	root.applyDetectedFormat(ctx, m, formatLogfmt)
+	if m.detectedFormat != formatLogfmt || m.formatApplied {
+		t.Errorf("applyDetectedFormat() format = %v, applied = %v", m.detectedFormat, m.formatApplied)
+	}
+	if got := root.StrLeftStatus(false); strings.Contains(got, "("+formatLogfmt+")") {
+		t.Errorf("StrLeftStatus() = %q, want no format without settings", got)
+	}
+	root.toggleDetectFormat(ctx)
+	if m.formatApplied {
+		t.Error("toggleDetectFormat() applied the format without settings")
+	}
+}
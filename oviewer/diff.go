@@ -0,0 +1,417 @@
+package oviewer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// diffLineType is the type of the line of the unified diff.
+type diffLineType int
+
+const (
+	// diffContext is the context line and other lines.
+	diffContext diffLineType = iota
+	// diffFileHeader is the header line of the file.
+	diffFileHeader
+	// diffHunkHeader is the header line of the hunk.
+	diffHunkHeader
+	// diffAdded is the added line.
+	diffAdded
+	// diffRemoved is the removed line.
+	diffRemoved
+)
+
+const (
+	// diffMaxPairLines is the maximum number of lines to search for the pair of the changed line.
+	diffMaxPairLines = 100
+	// diffMaxTokens is the maximum number of words to compare the changed lines.
+	diffMaxTokens = 200
+)
+
+var (
+	// diffHunkReg matches the hunk header and gets the number of lines.
+	diffHunkReg = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+\d+(?:,(\d+))? @@`)
+	// diffSectionReg matches the file headers and the hunk headers of git diff.
+	diffSectionReg = regexp.MustCompile(`^(diff |@@ )`)
+	// diffPatchSectionReg matches the file headers and the hunk headers of the diff without "diff" lines.
+	diffPatchSectionReg = regexp.MustCompile(`^(--- |@@ )`)
+)
+
+// diffLineStr returns the line without escape sequences.
+func (m *Document) diffLineStr(lN int) string {
+	str, err := m.LineStr(lN)
+	if err != nil {
+		return ""
+	}
+	return stripEscapeSequenceString(str)
+}
+
+// diffLineType returns the type of the line.
+// The lines of "---" and "+++" are the file header only if they are paired.
+func (m *Document) diffLineType(lN int, str string) diffLineType {
+	switch {
+	case strings.HasPrefix(str, "diff "), strings.HasPrefix(str, "index "):
+		return diffFileHeader
+	case strings.HasPrefix(str, "--- "):
+		if strings.HasPrefix(m.diffLineStr(lN+1), "+++ ") {
+			return diffFileHeader
+		}
+		return diffRemoved
+	case strings.HasPrefix(str, "+++ "):
+		if strings.HasPrefix(m.diffLineStr(lN-1), "--- ") {
+			return diffFileHeader
+		}
+		return diffAdded
+	case strings.HasPrefix(str, "@@"):
+		return diffHunkHeader
+	case strings.HasPrefix(str, "+"):
+		return diffAdded
+	case strings.HasPrefix(str, "-"):
+		return diffRemoved
+	}
+	return diffContext
+}
+
+// diffLineCache caches the types of the lines and the blocks of the changed lines during a draw,
+// because the pair of each drawn changed line is looked up from the lines around it.
+type diffLineCache struct {
+	types  map[int]diffLineType
+	blocks map[int][2]int
+}
+
+// diffLines returns the cache of the lines, which is reset for each draw.
+func (m *Document) diffLines() *diffLineCache {
+	if m.diffCache == nil {
+		m.diffCache = &diffLineCache{
+			types:  make(map[int]diffLineType),
+			blocks: make(map[int][2]int),
+		}
+	}
+	return m.diffCache
+}
+
+// diffType returns the type of the line using the cache.
+func (m *Document) diffType(lN int) diffLineType {
+	c := m.diffLines()
+	if t, ok := c.types[lN]; ok {
+		return t
+	}
+	t := m.diffLineType(lN, m.diffLineStr(lN))
+	c.types[lN] = t
+	return t
+}
+
+// diffStyle applies the styles of the diff to the line.
+func (root *Root) diffStyle(lN int, lineC LineC) {
+	m := root.Doc
+	switch m.diffType(lN) {
+	case diffFileHeader:
+		RangeStyle(lineC.lc, 0, len(lineC.lc), m.Style.DiffFile)
+	case diffHunkHeader:
+		RangeStyle(lineC.lc, 0, len(lineC.lc), m.Style.DiffHunk)
+	case diffAdded:
+		RangeStyle(lineC.lc, 0, len(lineC.lc), m.Style.DiffAdded)
+		m.diffWordStyle(lN, lineC, diffAdded)
+	case diffRemoved:
+		RangeStyle(lineC.lc, 0, len(lineC.lc), m.Style.DiffRemoved)
+		m.diffWordStyle(lN, lineC, diffRemoved)
+	}
+}
+
+// diffWordStyle applies the style to the words changed from the paired line.
+func (m *Document) diffWordStyle(lN int, lineC LineC, t diffLineType) {
+	pair, ok := m.diffPairLine(lN, t)
+	if !ok {
+		return
+	}
+	pairC := m.getLineC(pair)
+	if !pairC.valid || len(lineC.str) == 0 || len(pairC.str) == 0 {
+		return
+	}
+	for _, r := range diffChangedRanges(lineC.str[1:], pairC.str[1:]) {
+		RangeStyle(lineC.lc, lineC.pos.x(r[0]+1), lineC.pos.x(r[1]+1), m.Style.DiffWord)
+	}
+}
+
+// diffPairLine returns the line number of the paired line of the changed line.
+// The n-th removed line in a block is paired with the n-th added line of the following block.
+func (m *Document) diffPairLine(lN int, t diffLineType) (int, bool) {
+	start, end := m.diffBlock(lN, t)
+	n := lN - start
+	switch t {
+	case diffRemoved:
+		if m.diffType(end) != diffAdded {
+			return 0, false
+		}
+		_, addedEnd := m.diffBlock(end, diffAdded)
+		if n < addedEnd-end {
+			return end + n, true
+		}
+	case diffAdded:
+		prev := start - 1
+		if m.diffType(prev) != diffRemoved {
+			return 0, false
+		}
+		removedStart, _ := m.diffBlock(prev, diffRemoved)
+		if removedStart+n <= prev {
+			return removedStart + n, true
+		}
+	}
+	return 0, false
+}
+
+// diffBlock returns the first line and the line after the block of the same type.
+// The bounds are cached for all the lines of the block.
+func (m *Document) diffBlock(lN int, t diffLineType) (int, int) {
+	c := m.diffLines()
+	if b, ok := c.blocks[lN]; ok {
+		return b[0], b[1]
+	}
+	start := lN
+	for i := 0; i < diffMaxPairLines && start > 0 && m.diffType(start-1) == t; i++ {
+		start--
+	}
+	end := lN + 1
+	for i := 1; i < diffMaxPairLines && m.diffType(end) == t; i++ {
+		end++
+	}
+	for i := start; i < end; i++ {
+		c.blocks[i] = [2]int{start, end}
+	}
+	return start, end
+}
+
+// diffTokens splits the string into words, spaces and symbols.
+// It returns the start positions of the tokens and the end of the string.
+func diffTokens(str string) ([]string, []int) {
+	var tokens []string
+	var pos []int
+	start := 0
+	class := -1
+	for i, r := range str {
+		c := runeClass(r)
+		if i > 0 && (c != class || c == 2) {
+			tokens = append(tokens, str[start:i])
+			pos = append(pos, start)
+			start = i
+		}
+		class = c
+	}
+	if start < len(str) {
+		tokens = append(tokens, str[start:])
+		pos = append(pos, start)
+	}
+	return tokens, append(pos, len(str))
+}
+
+// runeClass returns 0 for the characters of the word, 1 for spaces and 2 for others.
+func runeClass(r rune) int {
+	switch {
+	case unicode.IsLetter(r), unicode.IsDigit(r), r == '_':
+		return 0
+	case unicode.IsSpace(r):
+		return 1
+	}
+	return 2
+}
+
+// diffChangedRanges returns the byte ranges of the words in a that are not in b.
+// It returns nil if a and b have no words in common.
+func diffChangedRanges(a string, b string) [][2]int {
+	ta, pos := diffTokens(a)
+	tb, _ := diffTokens(b)
+	if len(ta) == 0 || len(tb) == 0 || len(ta) > diffMaxTokens || len(tb) > diffMaxTokens {
+		return nil
+	}
+	// Longest common subsequence of the tokens.
+	lcs := make([][]int, len(ta)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(tb)+1)
+	}
+	for i := len(ta) - 1; i >= 0; i-- {
+		for j := len(tb) - 1; j >= 0; j-- {
+			if ta[i] == tb[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	common := make([]bool, len(ta))
+	words := 0
+	for i, j := 0, 0; i < len(ta) && j < len(tb); {
+		switch {
+		case ta[i] == tb[j]:
+			common[i] = true
+			if strings.TrimSpace(ta[i]) != "" {
+				words++
+			}
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	if words == 0 {
+		return nil
+	}
+	var ranges [][2]int
+	for i := range ta {
+		if common[i] {
+			continue
+		}
+		if len(ranges) > 0 && ranges[len(ranges)-1][1] == pos[i] {
+			ranges[len(ranges)-1][1] = pos[i+1]
+			continue
+		}
+		ranges = append(ranges, [2]int{pos[i], pos[i+1]})
+	}
+	return ranges
+}
+
+// diffSectionRegexp returns the regular expression of the sections of the diff.
+// The file headers are the "diff" lines if they exist, otherwise the "---" lines.
+func (m *Document) diffSectionRegexp() *regexp.Regexp {
+	if m.diffSectionReg != nil {
+		return m.diffSectionReg
+	}
+	reg := diffPatchSectionReg
+	for _, line := range m.sampleLines(context.Background(), detectSampleLines) {
+		if strings.HasPrefix(stripEscapeSequenceString(line), "diff ") {
+			reg = diffSectionReg
+			break
+		}
+	}
+	m.diffSectionReg = reg
+	return reg
+}
+
+// diffEntry is a file or a hunk of the diff.
+type diffEntry struct {
+	lN      int
+	name    string
+	hunk    bool
+	added   int
+	removed int
+}
+
+// label returns the label of the entry with the number of added and removed lines.
+func (e diffEntry) label() string {
+	return fmt.Sprintf("%s \x1b[32m+%d\x1b[0m \x1b[31m-%d\x1b[0m", e.name, e.added, e.removed)
+}
+
+// diffEntries returns the files and the hunks of the diff.
+func (m *Document) diffEntries(ctx context.Context) []diffEntry {
+	var entries []diffEntry
+	file, hunk := -1, -1
+	inHeader := false
+	oldLines, newLines := 0, 0
+	err := m.eachLine(ctx, 0, func(lN int, line []byte) bool {
+		str := stripEscapeSequenceString(string(line))
+		if oldLines > 0 || newLines > 0 {
+			switch {
+			case strings.HasPrefix(str, "+"):
+				entries[hunk].added++
+				entries[file].added++
+				newLines--
+			case strings.HasPrefix(str, "-"):
+				entries[hunk].removed++
+				entries[file].removed++
+				oldLines--
+			case strings.HasPrefix(str, "\\"):
+				// "\ No newline at end of file".
+			default:
+				oldLines--
+				newLines--
+			}
+			return true
+		}
+		switch {
+		case strings.HasPrefix(str, "diff "):
+			fields := strings.Fields(str)
+			entries = append(entries, diffEntry{lN: lN, name: diffFileName(fields[len(fields)-1])})
+			file, inHeader = len(entries)-1, true
+		case strings.HasPrefix(str, "--- "):
+			if !inHeader {
+				entries = append(entries, diffEntry{lN: lN})
+				file, inHeader = len(entries)-1, true
+			}
+			if entries[file].name == "" {
+				entries[file].name = diffFileName(str[4:])
+			}
+		case strings.HasPrefix(str, "+++ ") && file >= 0:
+			if name := diffFileName(str[4:]); name != "/dev/null" {
+				entries[file].name = name
+			}
+		default:
+			match := diffHunkReg.FindStringSubmatch(str)
+			if match == nil || file < 0 {
+				return true
+			}
+			entries = append(entries, diffEntry{lN: lN, name: str, hunk: true})
+			hunk, inHeader = len(entries)-1, false
+			oldLines, newLines = diffHunkLines(match[1]), diffHunkLines(match[2])
+		}
+		return true
+	})
+	if err != nil {
+		log.Printf("failed to read diff: %v\n", err)
+	}
+	return entries
+}
+
+// diffFileName returns the file name of the header without the timestamp and the prefix of git.
+func diffFileName(str string) string {
+	name, _, _ := strings.Cut(str, "\t")
+	name = strings.TrimSpace(name)
+	if strings.HasPrefix(name, "a/") || strings.HasPrefix(name, "b/") {
+		return name[2:]
+	}
+	return name
+}
+
+// diffHunkLines returns the number of lines of the hunk header.
+// The number is 1 if it is omitted.
+func diffHunkLines(str string) int {
+	if str == "" {
+		return 1
+	}
+	n, err := strconv.Atoi(str)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// diffSections returns the section list of the files and the hunks with the number of changed lines.
+// The hunks are one level below the files.
+func (m *Document) diffSections(ctx context.Context, offset int) MatchedLineList {
+	entries := m.diffEntries(ctx)
+	sections := make(MatchedLineList, 0, len(entries))
+	for _, e := range entries {
+		level := 0
+		if e.hunk {
+			level = 1
+		}
+		sections = append(sections, MatchedLine{lineNum: e.lN + offset, line: []byte(e.label()), level: level})
+	}
+	return sections
+}
+
+// toggleDiffMode toggles the diff mode.
+func (root *Root) toggleDiffMode(context.Context) {
+	m := root.Doc
+	m.DiffMode = !m.DiffMode
+	m.diffSectionReg = nil
+	m.diffCache = nil
+	m.sectionListDirty = true
+	root.generateSectionList()
+	root.setMessagef("Set DiffMode %t", m.DiffMode)
+}
@@ -0,0 +1,191 @@
+package oviewer
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDocument_diffLineType(t *testing.T) {
+	t.Parallel()
+	m := docFileReadHelper(t, filepath.Join(testdata, "diff.patch"))
+	tests := []struct {
+		lN   int
+		want diffLineType
+	}{
+		{lN: 0, want: diffFileHeader},
+		{lN: 1, want: diffFileHeader},
+		{lN: 2, want: diffFileHeader},
+		{lN: 3, want: diffFileHeader},
+		{lN: 4, want: diffHunkHeader},
+		{lN: 5, want: diffContext},
+		{lN: 7, want: diffRemoved},
+		{lN: 8, want: diffAdded},
+		{lN: 12, want: diffAdded},
+	}
+	for _, tt := range tests {
+		if got := m.diffLineType(tt.lN, m.diffLineStr(tt.lN)); got != tt.want {
+			t.Errorf("diffLineType(%d) = %v, want %v", tt.lN, got, tt.want)
+		}
+	}
+}
+
+func TestDocument_diffPairLine(t *testing.T) {
+	t.Parallel()
+	m := docHelper(t, "@@ -1,3 +1,3 @@\n-a\n-b\n+A\n+B\n+C\n c\n")
+	tests := []struct {
+		lN     int
+		t      diffLineType
+		want   int
+		wantOK bool
+	}{
+		{lN: 1, t: diffRemoved, want: 3, wantOK: true},
+		{lN: 2, t: diffRemoved, want: 4, wantOK: true},
+		{lN: 3, t: diffAdded, want: 1, wantOK: true},
+		{lN: 4, t: diffAdded, want: 2, wantOK: true},
+		{lN: 5, t: diffAdded, want: 0, wantOK: false},
+	}
+	for _, tt := range tests {
+		got, ok := m.diffPairLine(tt.lN, tt.t)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("diffPairLine(%d) = %d, %v, want %d, %v", tt.lN, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestDocument_diffBlock(t *testing.T) {
+	t.Parallel()
+	m := docHelper(t, "@@ -1,3 +1,3 @@\n-a\n-b\n+A\n+B\n+C\n c\n")
+	tests := []struct {
+		lN        int
+		t         diffLineType
+		wantStart int
+		wantEnd   int
+	}{
+		{lN: 1, t: diffRemoved, wantStart: 1, wantEnd: 3},
+		{lN: 2, t: diffRemoved, wantStart: 1, wantEnd: 3},
+		{lN: 4, t: diffAdded, wantStart: 3, wantEnd: 6},
+	}
+	for _, tt := range tests {
+		start, end := m.diffBlock(tt.lN, tt.t)
+		if start != tt.wantStart || end != tt.wantEnd {
+			t.Errorf("diffBlock(%d) = %d, %d, want %d, %d", tt.lN, start, end, tt.wantStart, tt.wantEnd)
+		}
+	}
+	if got := m.diffLines().blocks[5]; got != [2]int{3, 6} {
+		t.Errorf("diffBlock() cached %v for line 5, want %v", got, [2]int{3, 6})
+	}
+}
+
+func Test_diffChangedRanges(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want [][2]int
+	}{
+		{
+			name: "changed word",
+			a:    `return "world"`,
+			b:    `return "hello"`,
+			want: [][2]int{{8, 13}},
+		},
+		{
+			name: "added words",
+			a:    "a b c",
+			b:    "a c",
+			want: [][2]int{{2, 4}},
+		},
+		{
+			name: "no common words",
+			a:    "foo",
+			b:    "bar",
+			want: nil,
+		},
+		{
+			name: "same",
+			a:    "foo bar",
+			b:    "foo bar",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := diffChangedRanges(tt.a, tt.b); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffChangedRanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocument_diffEntries(t *testing.T) {
+	t.Parallel()
+	m := docFileReadHelper(t, filepath.Join(testdata, "diff.patch"))
+	got := m.diffEntries(context.Background())
+	want := []diffEntry{
+		{lN: 0, name: "main.go", added: 2, removed: 1},
+		{lN: 4, name: "@@ -1,4 +1,4 @@ package main", hunk: true, added: 1, removed: 1},
+		{lN: 10, name: "@@ -10,2 +10,3 @@ func main() {", hunk: true, added: 1},
+		{lN: 14, name: "README.md", added: 1, removed: 1},
+		{lN: 18, name: "@@ -1 +1 @@", hunk: true, added: 1, removed: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffEntries() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDocument_diffSections(t *testing.T) {
+	t.Parallel()
+	m := docFileReadHelper(t, filepath.Join(testdata, "diff.patch"))
+	got := m.diffSections(context.Background(), 0)
+	wantLevels := []int{0, 1, 1, 0, 1}
+	if len(got) != len(wantLevels) {
+		t.Fatalf("diffSections() = %d sections, want %d", len(got), len(wantLevels))
+	}
+	for i, want := range wantLevels {
+		if got[i].level != want {
+			t.Errorf("diffSections()[%d] level = %d, want %d", i, got[i].level, want)
+		}
+	}
+	if want := "main.go \x1b[32m+2\x1b[0m \x1b[31m-1\x1b[0m"; string(got[0].line) != want {
+		t.Errorf("diffSections()[0] = %q, want %q", got[0].line, want)
+	}
+}
+
+func TestDocument_diffEntries_patch(t *testing.T) {
+	t.Parallel()
+	m := docHelper(t, "--- a.txt\t2024-01-01\n+++ b.txt\t2024-01-02\n@@ -1,2 +1,2 @@\n--- x\n+++ y\n")
+	got := m.diffEntries(context.Background())
+	want := []diffEntry{
+		{lN: 0, name: "b.txt", added: 1, removed: 1},
+		{lN: 2, name: "@@ -1,2 +1,2 @@", hunk: true, added: 1, removed: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffEntries() = %+v, want %+v", got, want)
+	}
+	if got := m.diffSectionRegexp(); got != diffPatchSectionReg {
+		t.Errorf("diffSectionRegexp() = %v, want %v", got, diffPatchSectionReg)
+	}
+}
+
+func TestDocument_nextSection_diff(t *testing.T) {
+	t.Parallel()
+	m := docFileReadHelper(t, filepath.Join(testdata, "diff.patch"))
+	m.DiffMode = true
+	ctx := context.Background()
+	want := []int{4, 10, 14, 18}
+	lN := 0
+	for _, w := range want {
+		got, err := m.nextSection(ctx, lN)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != w {
+			t.Errorf("nextSection(%d) = %d, want %d", lN, got, w)
+		}
+		lN = got
+	}
+}
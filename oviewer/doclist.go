@@ -45,6 +45,9 @@ func (root *Root) addDocument(ctx context.Context, addDoc *Document) {
 	}
 	root.DocList = append(root.DocList, addDoc)
 	root.mu.Unlock()
+	if root.Config.AutoDetect {
+		go root.sendDetectFormat(addDoc)
+	}
 
 	root.setDocumentNum(ctx, len(root.DocList)-1)
 	root.setMessageLogf("add %s%s", addDoc.FileName, addDoc.Caption)
@@ -256,7 +256,7 @@ func TestRoot_closeAllDocumentsOfType(t *testing.T) {
 			searcher := NewSearcher("test", nil, false, false)
 			ctx := context.Background()
 			for range tt.fields.count {
-				root.filterDocument(ctx, searcher)
+				root.filterDocument(ctx, searcher, false)
 			}
 			got, got1 := root.closeAllDocumentsOfType(DocFilter)
 			if got != tt.want {
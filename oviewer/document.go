@@ -2,6 +2,7 @@ package oviewer
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -27,6 +28,9 @@ const (
 	DocHelp
 	DocLog
 	DocFilter
+	DocColumns
+	DocSort
+	DocVT
 )
 
 // DocumentCacheSize is the maximum number of lines to cache in the LRU cache for each document.
@@ -46,6 +50,12 @@ func (d documentType) String() string {
 		return "log"
 	case DocFilter:
 		return "filter"
+	case DocColumns:
+		return "columns"
+	case DocSort:
+		return "sort"
+	case DocVT:
+		return "vt"
 	}
 	return "unknown"
 }
@@ -88,6 +98,14 @@ type Document struct {
 	followStore *store
 	// alignConv is an interface that converts alignment.
 	alignConv *align
+	// stableWidths is the column widths of align mode computed over the document.
+	stableWidths stableWidths
+	// stableRunning guards concurrent column widths computation.
+	stableRunning atomic.Bool
+	// transformWidths caches the widths of the transformed columns of each line.
+	transformWidths *lru.Cache[int, []int]
+	// transformWidthsKey identifies the settings the transformed widths were computed with.
+	transformWidthsKey string
 
 	// cond is a condition variable for synchronization.
 	cond *sync.Cond
@@ -116,8 +134,48 @@ type Document struct {
 	sectionList MatchedLineList
 	// sectionListDirty indicates if the section list is dirty and needs to be updated.
 	sectionListDirty bool
+	// diffSectionReg is the regular expression of the sections in the diff mode.
+	diffSectionReg *regexp.Regexp
+	// diffCache is the cache of the lines in the diff mode, which is reset for each draw.
+	diffCache *diffLineCache
+	// promptState is the state of the detection of the prompt marks of OSC 133.
+	promptState atomic.Int32
+	// promptChecked is the point of the document at which the prompt marks were last looked for.
+	promptChecked atomic.Int64
+	// promptLines caches whether each line has the prompt start mark.
+	promptLines *lru.Cache[int, bool]
+	// markdown is the information of the document scanned for the markdown converter.
+	markdown *markdownInfo
+	// markdownMu is a mutex for markdown.
+	markdownMu sync.Mutex
+	// markdownRunning is true while the markdown document is scanned in the background.
+	markdownRunning atomic.Bool
+	// rawFrom is the converter to return to when the raw converter is toggled off.
+	rawFrom string
+	// preprocessed is true if the content is the output of the preprocessor.
+	preprocessed atomic.Bool
+	// replacement is the name of the replacement file of the preprocessor,
+	// which is passed to LESSCLOSE when it is closed.
+	replacement string
 	// columnWidths is a slice of column widths.
 	columnWidths []int
+	// links is a list of links in the document.
+	links []linkItem
+	// linksEnd is the end of the buffer when the links were collected.
+	linksEnd int
+	// linkPoint is the position of the current link.
+	linkPoint int
+	// linksCollecting is true while the links are being collected in the background.
+	linksCollecting bool
+
+	// detectedFormat is the format detected from the first lines.
+	detectedFormat string
+	// undetectedSettings is the settings before the detected format is applied.
+	undetectedSettings *RunTimeSettings
+	// detectedSettings is the settings with the detected format applied.
+	detectedSettings RunTimeSettings
+	// formatApplied indicates whether the settings of the detected format are applied.
+	formatApplied bool
 
 	// RunTimeSettings contains runtime configuration options for the document.
 	RunTimeSettings
@@ -288,6 +346,8 @@ type columnRange struct {
 type MatchedLine struct {
 	lineNum int
 	line    []byte
+	// level is the indentation level of the line in the sidebar.
+	level int
 }
 
 // MatchedLineList is a slice of MatchedLine, representing a list of matched lines.
@@ -349,10 +409,30 @@ func (m *Document) converterType(name string) Converter {
 		return m.alignConv.clone()
 	case convWordWrap:
 		return newWordwrapConverter(m.bodyWidth)
+	case convJSON:
+		if !m.WrapMode {
+			return newJSONConverter(0, newJSONStyles(m.Style))
+		}
+		return newJSONConverter(m.bodyWidth, newJSONStyles(m.Style))
+	case convMarkdown:
+		return newMarkdownConverter(markdownLine{}, m.bodyWidth, newMarkdownStyles(m.Style))
+	case convVT:
+		// The vt converter renders the whole document into a new document by vtDocument,
+		// so the lines of the document itself are processed as escape sequences.
+		return newESConverter()
 	}
 	return newESConverter()
 }
 
+// lineConverter returns the Converter for the line.
+// The markdown converter needs the information of the line from the whole document.
+func (m *Document) lineConverter(lN int) Converter {
+	if m.Converter == convMarkdown {
+		return newMarkdownConverter(m.markdownLine(lN), m.bodyWidth, newMarkdownStyles(m.Style))
+	}
+	return m.converterType(m.Converter)
+}
+
 // OpenDocument opens a file specified by fileName and returns a Document.
 // If the fileName is "-", it reads from stdin. It returns an error if the file
 // cannot be opened, is a directory, or if there are issues initializing the Document.
@@ -381,12 +461,7 @@ func OpenDocument(fileName string) (*Document, error) {
 	if err != nil {
 		return nil, err
 	}
-	// Check if the file is seekable.
-	if n, err := f.Seek(1, io.SeekStart); n != 1 || err != nil {
-		m.seekable = false
-	} else {
-		_, _ = f.Seek(0, io.SeekStart)
-	}
+	m.seekable = isSeekable(f)
 
 	m.FileName = fileName
 	// Read the control file.
@@ -440,6 +515,31 @@ func (m *Document) Line(n int) ([]byte, error) {
 	return store.GetChunkLine(chunkNum, cn)
 }
 
+// eachLine calls fn with each line from start to the end of the buffer.
+// Chunks that are not in memory are loaded synchronously.
+// It stops when fn returns false or ctx is canceled.
+func (m *Document) eachLine(ctx context.Context, start int, fn func(lN int, line []byte) bool) error {
+	for lN := max(start, m.BufStartNum()); lN < m.BufEndNum(); lN++ {
+		select {
+		case <-ctx.Done():
+			return ErrCancel
+		default:
+		}
+		chunkNum, cn := chunkLineNum(lN)
+		if !m.store.isLoadedChunk(chunkNum, m.seekable) && !m.requestLoadSync(chunkNum) {
+			return fmt.Errorf("%w: chunk %d", ErrNotLoaded, chunkNum)
+		}
+		line, err := m.store.GetChunkLine(chunkNum, cn)
+		if err != nil {
+			return err
+		}
+		if !fn(lN, line) {
+			return nil
+		}
+	}
+	return nil
+}
+
 // GetChunkLine returns a specific line from a specified chunk.
 // Parameters:
 // - chunkNum: the chunk number to retrieve the line from.
@@ -576,6 +676,12 @@ func (m *Document) BufEOF() bool {
 // It does not take any parameters and does not return any values.
 func (m *Document) ClearCache() {
 	m.cache.Purge()
+	if m.transformWidths != nil {
+		m.transformWidths.Purge()
+	}
+	if m.promptLines != nil {
+		m.promptLines.Purge()
+	}
 }
 
 // contents returns the contents of a specific line number in the document buffer.
@@ -590,7 +696,7 @@ func (m *Document) contents(lN int) (contents, error) {
 	}
 
 	str, err := m.LineStr(lN)
-	conv := m.converterType(m.Converter)
+	conv := m.lineConverter(lN)
 	return parseString(conv, str, m.TabWidth), err
 }
 
@@ -600,7 +706,7 @@ func (m *Document) contentsLine(lN int) (contents, tcell.Style, error) {
 	}
 
 	str, err := m.LineStr(lN)
-	conv := m.converterType(m.Converter)
+	conv := m.lineConverter(lN)
 	lc, style := parseLine(conv, str, m.TabWidth)
 	return lc, style, err
 }
@@ -747,6 +853,7 @@ func (m *Document) unwatchMode() {
 // regexpCompile compiles the new document's regular expressions.
 func (m *Document) regexpCompile() {
 	m.ColumnDelimiterReg = condRegexpCompile(m.ColumnDelimiter)
+	m.setColumnRegexp(m.ColumnRegexp)
 	m.setSectionDelimiter(m.SectionDelimiter)
 	if len(m.MultiColorWords) > 0 {
 		m.setMultiColorWords(m.MultiColorWords)
@@ -759,6 +866,13 @@ func (m *Document) setDelimiter(delm string) {
 	m.ColumnDelimiterReg = condRegexpCompile(delm)
 }
 
+// setColumnRegexp sets the regular expression that splits the columns.
+// An invalid regular expression disables it.
+func (m *Document) setColumnRegexp(reg string) {
+	m.ColumnRegexp = reg
+	m.ColumnRegexpReg = columnRegexpCompile(reg)
+}
+
 // setSectionDelimiter sets the document section delimiter.
 func (m *Document) setSectionDelimiter(delm string) {
 	m.SectionDelimiter = delm
@@ -838,11 +952,21 @@ func (m *Document) close() {
 	}
 
 	closeFile(m.file)
+	m.runLessClose()
 	atomic.StoreInt32(&m.store.eof, 1)
 	atomic.StoreInt32(&m.closed, 1)
 	atomic.StoreInt32(&m.store.changed, 1)
 }
 
+// isSeekable returns true if the file can be seeked.
+func isSeekable(f *os.File) bool {
+	if n, err := f.Seek(1, io.SeekStart); n != 1 || err != nil {
+		return false
+	}
+	_, _ = f.Seek(0, io.SeekStart)
+	return true
+}
+
 // closeFile closes the given file and logs any errors encountered during the close operation.
 func closeFile(f io.Closer) {
 	if f == nil {
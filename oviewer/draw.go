@@ -36,6 +36,7 @@ func (root *Root) draw(ctx context.Context) {
 	root.drawBody()
 
 	root.drawRuler()
+	root.drawColumnNames()
 	root.drawHeader()
 	root.drawSectionHeader()
 	root.drawSelect()
@@ -121,7 +122,7 @@ func (root *Root) drawHeader() {
 			wrapNum = 0
 		}
 	}
-	if root.scr.headerEnd > 0 {
+	if root.scr.headerEnd > 0 || root.scr.columnNamesHeight > 0 {
 		root.applyStyleToLine(m.headerHeight-1, m.Style.HeaderBorder)
 	}
 }
@@ -353,14 +354,19 @@ func (root *Root) drawLineNumber(lN int, y int, valid bool) {
 	}
 
 	number := lN
+	firstLine := m.firstLine()
 	if m.lineNumMap != nil {
 		n, ok := m.lineNumMap.LoadForward(number)
 		if ok {
 			number = n
+			// The mapped line number is the line number of the parent document.
+			if m.parent != nil {
+				firstLine = m.parent.firstLine()
+			}
 		}
 	}
 	// Line numbers start at 1 except for skip and header lines.
-	number = number - m.firstLine() + 1
+	number = number - firstLine + 1
 
 	style := applyStyle(defaultStyle, m.Style.LineNumber)
 	numC := fmt.Sprintf("%*d ", root.Doc.lineNumberWidth-1, number)
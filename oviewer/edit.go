@@ -48,43 +48,43 @@ func (root *Root) edit(context.Context) {
 		}()
 	}
 
+	num := max(root.Doc.topLN+root.Doc.firstLine(), 0)
+	if err := root.runEditor(fileName, strconv.Itoa(num)); err != nil {
+		root.setMessageLog(err.Error())
+		return
+	}
+	// Reload the document after editing.
+	if !isTemp {
+		root.reload(root.Doc)
+		root.sendGoto(num + 1)
+	}
+}
+
+// runEditor suspends the current screen display and runs the editor
+// to edit the file at the line number.
+// It will return when you exit the editor.
+func (root *Root) runEditor(fileName string, numStr string) error {
 	stdin := os.Stdin
 	if !term.IsTerminal(int(stdin.Fd())) {
 		tty, err := getTTY()
 		if err != nil {
-			err = fmt.Errorf("failed to open tty: %w", err)
-			root.setMessageLog(err.Error())
-			return
+			return fmt.Errorf("failed to open tty: %w", err)
 		}
 		defer closeFile(tty)
 		stdin = tty
 	}
 
 	if err := root.Screen.Suspend(); err != nil {
-		root.setMessageLog(err.Error())
-		return
+		return err
 	}
-
-	var errMsg error
-	num := max(root.Doc.topLN+root.Doc.firstLine(), 0)
 	defer func() {
 		log.Println("Resume from editor")
 		if err := root.Screen.Resume(); err != nil {
 			log.Println(err)
 		}
-		if errMsg != nil {
-			root.setMessage(errMsg.Error())
-			return
-		}
-		// Reload the document after editing.
-		if !isTemp {
-			root.reload(root.Doc)
-			root.sendGoto(num + 1)
-		}
 	}()
-	editor := root.identifyEditor()
 
-	numStr := strconv.Itoa(num)
+	editor := root.identifyEditor()
 	command, args := replaceEditorArgs(editor, numStr, fileName)
 
 	log.Println("Editing with command:", command, "and args:", args)
@@ -93,9 +93,9 @@ func (root *Root) edit(context.Context) {
 	c.Stdout = os.Stdout
 	c.Stderr = os.Stderr
 	if err := c.Run(); err != nil {
-		errMsg = fmt.Errorf("failed to run editor command '%s %s': %w", command, strings.Join(args, " "), err)
-		log.Println(errMsg)
+		return fmt.Errorf("failed to run editor command '%s %s': %w", command, strings.Join(args, " "), err)
 	}
+	return nil
 }
 
 // saveTempFile saves the current document to a temporary file and returns its name.
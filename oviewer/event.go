@@ -65,7 +65,14 @@ func (root *Root) event(ctx context.Context, ev tcell.Event) bool {
 	case *eventNextBackSearch:
 		root.backSearch(ctx, ev.str, -1)
 	case *eventSearchMove:
-		root.searchGo(ctx, ev.ln, ev.searcher)
+		// The document may have been switched during the search.
+		if ev.m == root.Doc {
+			root.searchGo(ctx, ev.ln, ev.searcher)
+		}
+	case *eventSearchProgress:
+		root.setMessage(ev.msg)
+	case *eventMessageLog:
+		root.setMessageLog(ev.msg)
 	case *eventAddMarks:
 		root.addMarks(ctx, ev.marks)
 	case *eventUpdateSections:
@@ -77,6 +84,14 @@ func (root *Root) event(ctx context.Context, ev tcell.Event) bool {
 		}
 		root.Config.QuitSmall = false
 		root.notifyEOFReached(ev.m)
+	case *eventDetectFormat:
+		root.applyDetectedFormat(ctx, ev.m, ev.format)
+	case *eventColumnStats:
+		root.columnStats = ev.stats
+	case *eventStableWidths:
+		ev.m.setStableWidths(ev.widths)
+	case *eventAddLinks:
+		root.addLinks(ev.m, ev.start, ev.end, ev.links)
 
 	// Input confirmation action event.
 	case *eventConverter:
@@ -87,8 +102,22 @@ func (root *Root) event(ctx context.Context, ev tcell.Event) bool {
 		root.goLine(ev.value)
 	case *eventMarkGoto:
 		root.goMarkNumber(ev.value)
+	case *eventJumpOption:
+		root.jumpOption(ctx, ev.value)
 	case *eventStyleToggle:
 		root.validateStyle(ev.value)
+	case *eventJSONFields:
+		root.setJSONFields(ctx, ev.value)
+	case *eventLogfmtKeys:
+		root.setLogfmtKeys(ctx, ev.value)
+	case *eventSortColumn:
+		root.sortColumn(ctx, ev.value)
+	case *eventColumnStatsFilter:
+		root.columnStatsFilter(ctx, ev.value)
+	case *eventFilterExpr:
+		root.filterExpression(ctx, ev.value)
+	case *eventColumnTransform:
+		root.setColumnTransform(ctx, ev.value)
 	case *eventHeaderColumn:
 		root.setHeaderColumn(ev.value)
 	case *eventHeader:
@@ -99,6 +128,8 @@ func (root *Root) event(ctx context.Context, ev tcell.Event) bool {
 		root.setMultiColor(ev.value)
 	case *eventSaveBuffer:
 		root.saveBuffer(ev.value)
+	case *eventExport:
+		root.exportDocument(ctx, ev.value)
 	case *eventFirstSearch:
 		root.firstSearch(ctx, ev.value, ev.searchType)
 	case *eventSkipLines:
@@ -350,6 +381,20 @@ func (root *Root) sendAddDocument(m *Document) {
 	root.postEvent(ev)
 }
 
+// eventMessageLog represents the message to display from the goroutine.
+type eventMessageLog struct {
+	msg string
+	tcell.EventTime
+}
+
+// sendMessageLog fires the eventMessageLog event.
+func (root *Root) sendMessageLog(msg string) {
+	ev := &eventMessageLog{}
+	ev.msg = msg
+	ev.SetEventNow()
+	root.postEvent(ev)
+}
+
 // eventCloseDocument represents a close document event.
 type eventCloseDocument struct {
 	tcell.EventTime
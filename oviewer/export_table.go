@@ -0,0 +1,324 @@
+package oviewer
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// exportFormat represents the format of the table export.
+type exportFormat int
+
+const (
+	// exportCSV exports as CSV.
+	exportCSV exportFormat = iota
+	// exportTSV exports as TSV.
+	exportTSV
+	// exportJSON exports as a JSON array of objects.
+	exportJSON
+	// exportMarkdown exports as a Markdown table.
+	exportMarkdown
+	// exportASCII exports as an ASCII table.
+	exportASCII
+)
+
+// String returns the string representation of the export format.
+func (f exportFormat) String() string {
+	switch f {
+	case exportTSV:
+		return "tsv"
+	case exportJSON:
+		return "json"
+	case exportMarkdown:
+		return "markdown"
+	case exportASCII:
+		return "table"
+	}
+	return "csv"
+}
+
+// parseExportFormat parses the export format.
+func parseExportFormat(str string) (exportFormat, error) {
+	switch strings.ToLower(str) {
+	case "csv":
+		return exportCSV, nil
+	case "tsv":
+		return exportTSV, nil
+	case "json":
+		return exportJSON, nil
+	case "markdown", "md":
+		return exportMarkdown, nil
+	case "table", "ascii":
+		return exportASCII, nil
+	}
+	return exportCSV, fmt.Errorf("%w: %s", ErrInvalidExportFormat, str)
+}
+
+// exportTable is the table to export.
+type exportTable struct {
+	// names is the column names. It is nil if there is no header.
+	names []string
+	rows  [][]string
+}
+
+// tableToExport returns the column values of the body in the display order.
+// Hidden columns are removed. The column names are taken from the header.
+func (m *Document) tableToExport(ctx context.Context) (*exportTable, error) {
+	table := &exportTable{}
+	num := 0
+	transformer, transform := m.columnTransformer()
+	err := m.eachLine(ctx, m.firstLine(), func(_ int, line []byte) bool {
+		values := m.columnValues(string(line))
+		for i, v := range values {
+			if transform {
+				v = transformer.value(i, v)
+			}
+			values[i] = trimColumnValue(v)
+		}
+		num = max(num, len(values))
+		table.rows = append(table.rows, values)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if m.Header > 0 || m.ColumnRegexpReg != nil {
+		table.names = m.headerColumnNames()
+		num = max(num, len(table.names))
+		table.names = m.displayColumnValues(fillColumns(table.names, num, strconv.Itoa))
+	}
+	for i, row := range table.rows {
+		table.rows[i] = m.displayColumnValues(fillColumns(row, num, func(int) string { return "" }))
+	}
+	return table, nil
+}
+
+// fillColumns fills the values up to num columns.
+func fillColumns(values []string, num int, fill func(int) string) []string {
+	for i := range num {
+		if i >= len(values) {
+			values = append(values, fill(i))
+		} else if values[i] == "" {
+			values[i] = fill(i)
+		}
+	}
+	return values
+}
+
+// header returns the column names, falling back to the column indexes.
+func (t *exportTable) header() []string {
+	if t.names != nil {
+		return t.names
+	}
+	num := 0
+	if len(t.rows) > 0 {
+		num = len(t.rows[0])
+	}
+	return fillColumns(nil, num, strconv.Itoa)
+}
+
+// write writes the table in the format.
+func (t *exportTable) write(w io.Writer, format exportFormat) error {
+	switch format {
+	case exportTSV:
+		return t.writeCSV(w, '\t')
+	case exportJSON:
+		return t.writeJSON(w)
+	case exportMarkdown:
+		return t.writeMarkdown(w)
+	case exportASCII:
+		return t.writeASCII(w)
+	}
+	return t.writeCSV(w, ',')
+}
+
+// writeCSV writes the table as CSV with the delimiter.
+func (t *exportTable) writeCSV(w io.Writer, delimiter rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = delimiter
+	if t.names != nil {
+		if err := cw.Write(t.names); err != nil {
+			return err
+		}
+	}
+	if err := cw.WriteAll(t.rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// writeJSON writes the table as a JSON array of objects.
+// The keys are in the column order.
+func (t *exportTable) writeJSON(w io.Writer) error {
+	names := t.header()
+	var buf bytes.Buffer
+	buf.WriteString("[\n")
+	for i, row := range t.rows {
+		buf.WriteString("  {")
+		for j, value := range row {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+			key, err := json.Marshal(names[j])
+			if err != nil {
+				return err
+			}
+			val, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteString(": ")
+			buf.Write(val)
+		}
+		buf.WriteString("}")
+		if i < len(t.rows)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("]\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// columnWidths returns the display width of each column including the header.
+func (t *exportTable) columnWidths(names []string, escape func(string) string) []int {
+	widths := make([]int, len(names))
+	for i, name := range names {
+		widths[i] = stringWidth(escape(name))
+	}
+	for _, row := range t.rows {
+		for i, value := range row {
+			widths[i] = max(widths[i], stringWidth(escape(value)))
+		}
+	}
+	return widths
+}
+
+// tableRow returns the row of the table with the values padded to the widths.
+func tableRow(values []string, widths []int, escape func(string) string) string {
+	var sb strings.Builder
+	sb.WriteString("|")
+	for i, value := range values {
+		value = escape(value)
+		sb.WriteString(" ")
+		sb.WriteString(value)
+		sb.WriteString(strings.Repeat(" ", widths[i]-stringWidth(value)))
+		sb.WriteString(" |")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// markdownEscape escapes the pipe in the value of the Markdown table.
+func markdownEscape(value string) string {
+	return strings.ReplaceAll(value, "|", `\|`)
+}
+
+// noEscape returns the value as it is.
+func noEscape(value string) string {
+	return value
+}
+
+// writeMarkdown writes the table as a Markdown table.
+func (t *exportTable) writeMarkdown(w io.Writer) error {
+	names := t.header()
+	widths := t.columnWidths(names, markdownEscape)
+	var sb strings.Builder
+	sb.WriteString(tableRow(names, widths, markdownEscape))
+	sb.WriteString("|")
+	for _, width := range widths {
+		sb.WriteString(strings.Repeat("-", max(width, 1)+2))
+		sb.WriteString("|")
+	}
+	sb.WriteString("\n")
+	for _, row := range t.rows {
+		sb.WriteString(tableRow(row, widths, markdownEscape))
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeASCII writes the table as an ASCII table.
+func (t *exportTable) writeASCII(w io.Writer) error {
+	names := t.header()
+	widths := t.columnWidths(names, noEscape)
+	var border strings.Builder
+	border.WriteString("+")
+	for _, width := range widths {
+		border.WriteString(strings.Repeat("-", width+2))
+		border.WriteString("+")
+	}
+	border.WriteString("\n")
+
+	var sb strings.Builder
+	sb.WriteString(border.String())
+	if t.names != nil {
+		sb.WriteString(tableRow(names, widths, noEscape))
+		sb.WriteString(border.String())
+	}
+	for _, row := range t.rows {
+		sb.WriteString(tableRow(row, widths, noEscape))
+	}
+	sb.WriteString(border.String())
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// exportDocument exports the column values of the current document.
+// The input is the format optionally followed by the file name.
+// If the file name is omitted, the table is copied to the clipboard.
+func (root *Root) exportDocument(ctx context.Context, input string) {
+	m := root.Doc
+	if !m.ColumnMode {
+		root.setMessage("column mode is not enabled")
+		return
+	}
+	str, fileName, _ := strings.Cut(strings.TrimSpace(input), " ")
+	format, err := parseExportFormat(str)
+	if err != nil {
+		root.setMessage(err.Error())
+		return
+	}
+	table, err := m.tableToExport(ctx)
+	if err != nil {
+		root.setMessageLogf("cannot export: %s", err)
+		return
+	}
+
+	fileName = strings.TrimSpace(fileName)
+	if fileName == "" {
+		var buf bytes.Buffer
+		if err := table.write(&buf, format); err != nil {
+			root.setMessageLogf("cannot export: %s", err)
+			return
+		}
+		root.copyClipboard(buf.String())
+		root.setMessagef("copied %d rows as %s", len(table.rows), format)
+		return
+	}
+
+	flag, err := root.promptSaveFlag(fileName)
+	if err != nil {
+		root.setMessage("export cancel")
+		return
+	}
+	file, err := os.OpenFile(fileName, flag, os.FileMode(0o644))
+	if err != nil {
+		root.setMessageLogf("cannot export: %s:%s", fileName, err)
+		return
+	}
+	defer closeFile(file)
+	if err := table.write(file, format); err != nil {
+		root.setMessageLogf("cannot export: %s:%s", fileName, err)
+		return
+	}
+	root.setMessageLogf("exported %s as %s", fileName, format)
+}
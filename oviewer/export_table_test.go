@@ -0,0 +1,190 @@
+package oviewer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_parseExportFormat(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		str     string
+		want    exportFormat
+		wantErr error
+	}{
+		{str: "csv", want: exportCSV},
+		{str: "TSV", want: exportTSV},
+		{str: "json", want: exportJSON},
+		{str: "md", want: exportMarkdown},
+		{str: "table", want: exportASCII},
+		{str: "xml", want: exportCSV, wantErr: ErrInvalidExportFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseExportFormat(tt.str)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseExportFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseExportFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_exportTable_write(t *testing.T) {
+	t.Parallel()
+	table := &exportTable{
+		names: []string{"name", "note"},
+		rows: [][]string{
+			{"a", "x|y"},
+			{"bb", `say "hi"`},
+		},
+	}
+	tests := []struct {
+		name   string
+		format exportFormat
+		want   string
+	}{
+		{
+			name:   "csv",
+			format: exportCSV,
+			want:   "name,note\na,x|y\nbb,\"say \"\"hi\"\"\"\n",
+		},
+		{
+			name:   "tsv",
+			format: exportTSV,
+			want:   "name\tnote\na\tx|y\nbb\t\"say \"\"hi\"\"\"\n",
+		},
+		{
+			name:   "json",
+			format: exportJSON,
+			want:   "[\n  {\"name\": \"a\", \"note\": \"x|y\"},\n  {\"name\": \"bb\", \"note\": \"say \\\"hi\\\"\"}\n]\n",
+		},
+		{
+			name:   "markdown",
+			format: exportMarkdown,
+			want: "| name | note     |\n" +
+				"|------|----------|\n" +
+				"| a    | x\\|y     |\n" +
+				"| bb   | say \"hi\" |\n",
+		},
+		{
+			name:   "ascii",
+			format: exportASCII,
+			want: "+------+----------+\n" +
+				"| name | note     |\n" +
+				"+------+----------+\n" +
+				"| a    | x|y      |\n" +
+				"| bb   | say \"hi\" |\n" +
+				"+------+----------+\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			if err := table.write(&buf, tt.format); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("exportTable.write() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocument_tableToExport(t *testing.T) {
+	m := docFileReadHelper(t, filepath.Join(testdata, "sort.csv"))
+	m.ColumnMode = true
+	m.ColumnDelimiter = ","
+	m.Header = 1
+	m.regexpCompile()
+	table, err := m.tableToExport(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(table.names, ","); got != "name,size,count" {
+		t.Errorf("tableToExport() names = %v", got)
+	}
+	if len(table.rows) != 4 {
+		t.Fatalf("tableToExport() rows = %v, want 4", len(table.rows))
+	}
+	if got := strings.Join(table.rows[0], ","); got != "b,1.5K,10" {
+		t.Errorf("tableToExport() row = %v", got)
+	}
+
+	m.Header = 0
+	table, err = m.tableToExport(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.names != nil || len(table.rows) != 5 {
+		t.Errorf("tableToExport() without header = %v %v", table.names, len(table.rows))
+	}
+	if got := strings.Join(table.header(), ","); got != "0,1,2" {
+		t.Errorf("exportTable.header() = %v", got)
+	}
+}
+
+func TestRoot_exportDocument(t *testing.T) {
+	tests := []struct {
+		name        string
+		columnMode  bool
+		input       string
+		want        string
+		wantMessage string
+	}{
+		{
+			name:        "tsv",
+			columnMode:  true,
+			input:       "tsv exported",
+			want:        "name\tsize\tcount\nb\t1.5K\t10\n",
+			wantMessage: "exported ",
+		},
+		{
+			name:        "invalid format",
+			columnMode:  true,
+			input:       "xml exported",
+			wantMessage: "invalid export format",
+		},
+		{
+			name:        "not column mode",
+			columnMode:  false,
+			input:       "csv exported",
+			wantMessage: "column mode is not enabled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := rootFileReadHelper(t, filepath.Join(testdata, "sort.csv"))
+			root.Doc.WaitEOF()
+			m := root.Doc
+			m.ColumnMode = tt.columnMode
+			m.ColumnDelimiter = ","
+			m.Header = 1
+			m.regexpCompile()
+			fileName := filepath.Join(t.TempDir(), "exported")
+			root.exportDocument(context.Background(), strings.Replace(tt.input, "exported", fileName, 1))
+			if !strings.Contains(root.message, tt.wantMessage) {
+				t.Fatalf("exportDocument() message = %q, want substring %q", root.message, tt.wantMessage)
+			}
+			if tt.want == "" {
+				return
+			}
+			got, err := os.ReadFile(fileName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(string(got), tt.want) {
+				t.Errorf("exportDocument() wrote %q, want prefix %q", string(got), tt.want)
+			}
+		})
+	}
+}
@@ -28,12 +28,13 @@ func (root *Root) filter(ctx context.Context, str string) {
 	if searcher == nil {
 		return
 	}
-	root.filterDocument(ctx, searcher)
+	root.filterDocument(ctx, searcher, root.Doc.nonMatch)
 }
 
 // filterDocument filters the document by the searcher.
 // It creates a new document and writes the filtered lines to it.
-func (root *Root) filterDocument(ctx context.Context, searcher Searcher) {
+// If nonMatch is true, the lines that do not match are written.
+func (root *Root) filterDocument(ctx context.Context, searcher Searcher, nonMatch bool) {
 	m := root.Doc
 	r, w := io.Pipe()
 	render, err := renderDoc(m, r)
@@ -45,7 +46,7 @@ func (root *Root) filterDocument(ctx context.Context, searcher Searcher) {
 	}
 	render.documentType = DocFilter
 	match := searcher.String()
-	if m.nonMatch {
+	if nonMatch {
 		match = "!" + match
 	}
 	render.Caption = "filter:" + match
@@ -68,12 +69,12 @@ func (root *Root) filterDocument(ctx context.Context, searcher Searcher) {
 		render.lineNumMap.Store(ln, ln)
 		writeLine(w, line)
 	}
-	go m.filterWriter(ctx, searcher, m.firstLine(), filterDoc)
+	go m.filterWriter(ctx, searcher, nonMatch, m.firstLine(), filterDoc)
 	root.setMessage(msg)
 }
 
 // filterWriter searches and writes to filterDoc.
-func (m *Document) filterWriter(ctx context.Context, searcher Searcher, startLN int, filterDoc *filterDocument) {
+func (m *Document) filterWriter(ctx context.Context, searcher Searcher, nonMatch bool, startLN int, filterDoc *filterDocument) {
 	defer closeFile(filterDoc.w)
 	for originLN, renderLN := startLN, startLN; ; {
 		select {
@@ -81,7 +82,7 @@ func (m *Document) filterWriter(ctx context.Context, searcher Searcher, startLN
 			return
 		default:
 		}
-		lineNum, err := m.SearchLine(ctx, searcher, originLN)
+		lineNum, err := m.searchLineMatch(ctx, searcher, originLN, nonMatch, nil)
 		if err != nil {
 			// Not found
 			break
@@ -0,0 +1,388 @@
+package oviewer
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// filterExpr is a node of the filter expression.
+type filterExpr interface {
+	eval(values []string) bool
+}
+
+// exprAnd is the logical and of the expressions.
+type exprAnd struct {
+	left, right filterExpr
+}
+
+func (e exprAnd) eval(values []string) bool {
+	return e.left.eval(values) && e.right.eval(values)
+}
+
+// exprOr is the logical or of the expressions.
+type exprOr struct {
+	left, right filterExpr
+}
+
+func (e exprOr) eval(values []string) bool {
+	return e.left.eval(values) || e.right.eval(values)
+}
+
+// exprNot is the negation of the expression.
+type exprNot struct {
+	expr filterExpr
+}
+
+func (e exprNot) eval(values []string) bool {
+	return !e.expr.eval(values)
+}
+
+// exprOperand is a column reference or a literal value.
+type exprOperand struct {
+	// column is the column number. It is -1 if the operand is a literal.
+	column int
+	value  string
+}
+
+// get returns the value of the operand.
+func (o exprOperand) get(values []string) string {
+	if o.column < 0 {
+		return o.value
+	}
+	if o.column >= len(values) {
+		return ""
+	}
+	return trimColumnValue(values[o.column])
+}
+
+// exprCompare compares the operands.
+type exprCompare struct {
+	left  exprOperand
+	op    string
+	right exprOperand
+}
+
+func (e exprCompare) eval(values []string) bool {
+	c := compareValues(e.left.get(values), e.right.get(values))
+	switch e.op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+// compareValues compares the values as numbers, as human-readable sizes, or as strings.
+func compareValues(a, b string) int {
+	if x, ok := parseNumber(a); ok {
+		if y, ok := parseNumber(b); ok {
+			return cmp.Compare(x, y)
+		}
+	}
+	if x, ok := parseHumanSize(a); ok {
+		if y, ok := parseHumanSize(b); ok {
+			return cmp.Compare(x, y)
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// exprMatch matches the operand with the regular expression.
+type exprMatch struct {
+	left  exprOperand
+	re    *regexp.Regexp
+	match bool
+}
+
+func (e exprMatch) eval(values []string) bool {
+	return e.re.MatchString(e.left.get(values)) == e.match
+}
+
+// exprToken is a token of the filter expression.
+type exprToken struct {
+	str string
+	// quoted is true if the token is a quoted string or a quoted column name.
+	quoted bool
+	// column is true if the token is a column name quoted with backquotes.
+	column bool
+}
+
+// exprOperators is the list of operators, longest first.
+var exprOperators = []string{"==", "!=", "<=", ">=", "=~", "!~", "&&", "||", "<", ">", "=", "!", "(", ")"}
+
+// tokenizeExpr splits the filter expression into tokens.
+func tokenizeExpr(str string) ([]exprToken, error) {
+	var tokens []exprToken
+	for i := 0; i < len(str); {
+		c := str[i]
+		if c == ' ' || c == '\t' {
+			i++
+			continue
+		}
+		if c == '"' || c == '\'' || c == '`' {
+			end := strings.IndexByte(str[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated quote", ErrInvalidFilterExpr)
+			}
+			tokens = append(tokens, exprToken{str: str[i+1 : i+1+end], quoted: true, column: c == '`'})
+			i += end + 2
+			continue
+		}
+		if op := exprOperatorAt(str[i:]); op != "" {
+			tokens = append(tokens, exprToken{str: op})
+			i += len(op)
+			continue
+		}
+		start := i
+		for i < len(str) && !strings.ContainsRune(" \t\"'`", rune(str[i])) && exprOperatorAt(str[i:]) == "" {
+			i++
+		}
+		tokens = append(tokens, exprToken{str: str[start:i]})
+	}
+	return tokens, nil
+}
+
+// exprOperatorAt returns the operator at the beginning of the string.
+func exprOperatorAt(str string) string {
+	for _, op := range exprOperators {
+		if strings.HasPrefix(str, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// exprParser is a recursive descent parser of the filter expression.
+//
+//	expr       = and { ("or" | "||") and }
+//	and        = not { ("and" | "&&") not }
+//	not        = ("not" | "!") not | primary
+//	primary    = "(" expr ")" | comparison
+//	comparison = operand ("==" | "=" | "!=" | "<" | "<=" | ">" | ">=" | "=~" | "!~") operand
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+	names  []string
+}
+
+// parseFilterExpr parses the filter expression.
+// Columns are referenced by the header name, `quoted name` or $index.
+// Other words are literal values.
+func parseFilterExpr(str string, names []string) (filterExpr, error) {
+	tokens, err := tokenizeExpr(str)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty expression", ErrInvalidFilterExpr)
+	}
+	p := &exprParser{tokens: tokens, names: names}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidFilterExpr, p.tokens[p.pos].str)
+	}
+	return expr, nil
+}
+
+// peek returns the current operator or keyword. It returns an empty string for quoted tokens.
+func (p *exprParser) peek() string {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].quoted {
+		return ""
+	}
+	return p.tokens[p.pos].str
+}
+
+func (p *exprParser) parseOr() (filterExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch strings.ToLower(p.peek()) {
+		case "or", "||":
+			p.pos++
+			right, err := p.parseAnd()
+			if err != nil {
+				return nil, err
+			}
+			left = exprOr{left: left, right: right}
+		default:
+			return left, nil
+		}
+	}
+}
+
+func (p *exprParser) parseAnd() (filterExpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch strings.ToLower(p.peek()) {
+		case "and", "&&":
+			p.pos++
+			right, err := p.parseNot()
+			if err != nil {
+				return nil, err
+			}
+			left = exprAnd{left: left, right: right}
+		default:
+			return left, nil
+		}
+	}
+}
+
+func (p *exprParser) parseNot() (filterExpr, error) {
+	switch strings.ToLower(p.peek()) {
+	case "not", "!":
+		p.pos++
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return exprNot{expr: expr}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (filterExpr, error) {
+	if p.peek() == "(" {
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("%w: missing )", ErrInvalidFilterExpr)
+		}
+		p.pos++
+		return expr, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (filterExpr, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	op := p.peek()
+	switch op {
+	case "==", "=", "!=", "<", "<=", ">", ">=", "=~", "!~":
+		p.pos++
+	default:
+		return nil, fmt.Errorf("%w: missing operator after %q", ErrInvalidFilterExpr, p.tokens[p.pos-1].str)
+	}
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case "=~", "!~":
+		re, err := regexp.Compile(right.value)
+		if err != nil || right.column >= 0 {
+			return nil, fmt.Errorf("%w: invalid regular expression %q", ErrInvalidFilterExpr, right.value)
+		}
+		return exprMatch{left: left, re: re, match: op == "=~"}, nil
+	case "=":
+		op = "=="
+	}
+	return exprCompare{left: left, op: op, right: right}, nil
+}
+
+func (p *exprParser) parseOperand() (exprOperand, error) {
+	if p.pos >= len(p.tokens) {
+		return exprOperand{}, fmt.Errorf("%w: unexpected end", ErrInvalidFilterExpr)
+	}
+	token := p.tokens[p.pos]
+	if !token.quoted && exprOperatorAt(token.str) != "" {
+		return exprOperand{}, fmt.Errorf("%w: unexpected %q", ErrInvalidFilterExpr, token.str)
+	}
+	p.pos++
+	switch {
+	case token.column:
+		n, ok := columnIndex(token.str, p.names)
+		if !ok {
+			return exprOperand{}, fmt.Errorf("%w: no column %q", ErrInvalidFilterExpr, token.str)
+		}
+		return exprOperand{column: n}, nil
+	case token.quoted:
+		return exprOperand{column: -1, value: token.str}, nil
+	case strings.HasPrefix(token.str, "$"):
+		n, err := strconv.Atoi(token.str[1:])
+		if err != nil || n < 0 {
+			return exprOperand{}, fmt.Errorf("%w: invalid column %q", ErrInvalidFilterExpr, token.str)
+		}
+		return exprOperand{column: n}, nil
+	}
+	for i, name := range p.names {
+		if name != "" && name == token.str {
+			return exprOperand{column: i}, nil
+		}
+	}
+	return exprOperand{column: -1, value: token.str}, nil
+}
+
+// exprSearcher matches the lines for which the filter expression is true.
+// The columns are split with the settings taken when the expression is compiled.
+type exprSearcher struct {
+	splitter columnSplitter
+	expr     filterExpr
+	str      string
+}
+
+// Match returns true if the expression is true for the line.
+func (s exprSearcher) Match(target []byte) bool {
+	return s.MatchString(string(target))
+}
+
+// MatchString returns true if the expression is true for the line.
+func (s exprSearcher) MatchString(target string) bool {
+	return s.expr.eval(s.splitter.values(target))
+}
+
+// FindAll returns nil because the whole line is matched.
+func (exprSearcher) FindAll(string) [][]int {
+	return nil
+}
+
+// String returns the expression.
+func (s exprSearcher) String() string {
+	return s.str
+}
+
+// filterExpression filters the document by the filter expression over the columns.
+func (root *Root) filterExpression(ctx context.Context, input string) {
+	m := root.Doc
+	if !m.ColumnMode {
+		root.setMessage("column mode is not enabled")
+		return
+	}
+	expr, err := parseFilterExpr(input, m.headerColumnNames())
+	if err != nil {
+		root.setMessage(err.Error())
+		return
+	}
+	searcher := exprSearcher{
+		splitter: m.columnSplitter(),
+		expr:     expr,
+		str:      strings.TrimSpace(input),
+	}
+	root.filterDocument(ctx, searcher, false)
+}
@@ -0,0 +1,110 @@
+package oviewer
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+func Test_parseFilterExpr(t *testing.T) {
+	t.Parallel()
+	names := []string{"USER", "%CPU", "RSS", "TIME COMMAND"}
+	values := []string{"postgres", "52.5", "1.5M", "sleep"}
+	tests := []struct {
+		name    string
+		expr    string
+		want    bool
+		wantErr error
+	}{
+		{name: "numeric", expr: "%CPU > 50", want: true},
+		{name: "numeric false", expr: "%CPU <= 50", want: false},
+		{name: "string equal", expr: "USER == postgres", want: true},
+		{name: "single equal", expr: "USER = 'postgres'", want: true},
+		{name: "not equal", expr: `USER != "root"`, want: true},
+		{name: "human size", expr: "RSS > 512K", want: true},
+		{name: "index", expr: "$0 == postgres && $1 >= 52.5", want: true},
+		{name: "quoted name", expr: "`TIME COMMAND` =~ ^sl", want: true},
+		{name: "not match", expr: "USER !~ '^post'", want: false},
+		{name: "and or", expr: "USER == root and %CPU > 50 or RSS < 2M", want: true},
+		{name: "parentheses", expr: "USER == root and (%CPU > 50 or RSS < 2M)", want: false},
+		{name: "not", expr: "not USER == root", want: true},
+		{name: "bang", expr: "!(USER == postgres)", want: false},
+		{name: "missing column", expr: "$9 == ''", want: true},
+		{name: "empty", expr: " ", wantErr: ErrInvalidFilterExpr},
+		{name: "no operator", expr: "USER", wantErr: ErrInvalidFilterExpr},
+		{name: "unterminated", expr: "USER == 'x", wantErr: ErrInvalidFilterExpr},
+		{name: "missing paren", expr: "(USER == x", wantErr: ErrInvalidFilterExpr},
+		{name: "unknown column", expr: "`PID` == 1", wantErr: ErrInvalidFilterExpr},
+		{name: "invalid regexp", expr: "USER =~ '('", wantErr: ErrInvalidFilterExpr},
+		{name: "trailing", expr: "USER == x y", wantErr: ErrInvalidFilterExpr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			expr, err := parseFilterExpr(tt.expr, names)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseFilterExpr() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := expr.eval(values); got != tt.want {
+				t.Errorf("parseFilterExpr() eval = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoot_filterExpression(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "sort.csv"))
+	root.Doc.WaitEOF()
+	m := root.Doc
+	m.ColumnMode = true
+	m.ColumnDelimiter = ","
+	m.Header = 1
+	m.regexpCompile()
+	ctx := context.Background()
+
+	root.filterExpression(ctx, "size >")
+	if root.DocumentLen() != 1 {
+		t.Fatalf("filterExpression() with an invalid expression created a document")
+	}
+	// The non-match toggle of the user is kept and not applied.
+	m.nonMatch = true
+	root.filterExpression(ctx, "size > 1K and name != d")
+	if root.DocumentLen() != 2 {
+		t.Fatalf("filterExpression() documents = %v, want 2", root.DocumentLen())
+	}
+	if !m.nonMatch {
+		t.Error("filterExpression() reset nonMatch")
+	}
+	doc := root.DocList[len(root.DocList)-1]
+	doc.WaitEOF()
+	if doc.Caption != "filter:size > 1K and name != d" {
+		t.Errorf("filterExpression() caption = %v", doc.Caption)
+	}
+	want := []string{"name,size,count", "b,1.5K,10", "a,2M,100"}
+	if doc.BufEndNum() != len(want) {
+		t.Fatalf("filterExpression() lines = %v, want %v", doc.BufEndNum(), len(want))
+	}
+	wantOrigin := []int{0, 1, 3}
+	for lN, w := range want {
+		line, err := doc.Line(lN)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(line) != w {
+			t.Errorf("filterExpression() line %d = %v, want %v", lN, string(line), w)
+		}
+		if origin, ok := doc.lineNumMap.LoadForward(lN); !ok || origin != wantOrigin[lN] {
+			t.Errorf("filterExpression() lineNumMap %d = %v, want %v", lN, origin, wantOrigin[lN])
+		}
+	}
+}
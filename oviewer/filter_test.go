@@ -172,7 +172,7 @@ func TestRoot_filterDocument(t *testing.T) {
 			root := rootFileReadHelper(t, tt.fields.fileNames...)
 			root.Doc.SkipLines = tt.fields.skipLines
 			root.Doc.Header = tt.fields.header
-			root.filterDocument(context.Background(), tt.args.searcher)
+			root.filterDocument(context.Background(), tt.args.searcher, false)
 			filterDoc := root.DocList[len(root.DocList)-1]
 			filterDoc.cond.L.Lock()
 			filterDoc.cond.Wait()
@@ -195,7 +195,7 @@ func TestRoot_filterCancel(t *testing.T) {
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
 	cancel()
-	root.filterDocument(ctx, searcher)
+	root.filterDocument(ctx, searcher, false)
 	if root.DocumentLen() != 2 {
 		t.Errorf("filterDocument() = %v, want %v", root.DocumentLen(), 2)
 	}
@@ -241,7 +241,7 @@ func TestRoot_filterLink(t *testing.T) {
 			root.Doc.Header = tt.fields.header
 			root.input.value = "1000"
 			ctx := context.Background()
-			root.filterDocument(ctx, tt.args.searcher)
+			root.filterDocument(ctx, tt.args.searcher, false)
 			filterDoc := root.DocList[len(root.DocList)-1]
 			filterDoc.WaitEOF()
 			filterDoc.LineNumMode = true
@@ -297,7 +297,7 @@ func TestRoot_closeAllFilter(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			root := rootFileReadHelper(t, tt.fields.fileNames...)
-			root.filterDocument(context.Background(), tt.args.searcher)
+			root.filterDocument(context.Background(), tt.args.searcher, false)
 			filterDoc := root.DocList[len(root.DocList)-1]
 			filterDoc.WaitEOF()
 			ctx := context.Background()
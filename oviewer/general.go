@@ -12,14 +12,28 @@ type General struct {
 	Wrap *string
 	// Caption is an additional caption to display after the file name.
 	Caption *string
+	// Syntax is the language of the syntax highlighting, or "auto" to select it by the file extension.
+	Syntax *string
 	// ColumnDelimiter is a column delimiter.
 	ColumnDelimiter *string
+	// ColumnRegexp is a regular expression with named groups that splits the columns.
+	ColumnRegexp *string
 	// SectionDelimiter is a section delimiter.
 	SectionDelimiter *string
 	// JumpTarget is the configured jump target string.
 	JumpTarget *string
 	// MultiColorWords specifies words to color separated by spaces.
 	MultiColorWords *[]string
+	// ColumnHide specifies the columns to hide by index or header name (align mode only).
+	ColumnHide *[]string
+	// ColumnOrder specifies the columns to display first by index or header name (align mode only).
+	ColumnOrder *[]string
+	// ColumnTransform specifies the display transforms of the columns as "column:transform" (align mode only).
+	ColumnTransform *[]string
+	// ColumnNames specifies the names of the columns for data without a header line.
+	ColumnNames *[]string
+	// LogfmtKeys specifies the logfmt keys to display as columns.
+	LogfmtKeys *[]string
 
 	// TabWidth is the tab stop width.
 	TabWidth *int
@@ -56,6 +70,13 @@ type General struct {
 	ColumnWidth *bool
 	// ColumnRainbow is column rainbow.
 	ColumnRainbow *bool
+	// AlignStable computes the column widths of align mode over the whole document.
+	AlignStable *bool
+	// AlignSample is the number of lines to compute the column widths with AlignStable.
+	// If 0 is specified, all lines are computed.
+	AlignSample *int
+	// ApplyTransform applies the column transforms also to search, filter and export.
+	ApplyTransform *bool
 	// LineNumMode displays line numbers.
 	LineNumMode *bool
 	// WrapMode indicates whether wrapping is enabled.
@@ -74,6 +95,10 @@ type General struct {
 	SectionHeader *bool
 	// HideOtherSection is whether to hide other sections.
 	HideOtherSection *bool
+	// DiffMode is whether to display the unified diff with styles and sections.
+	DiffMode *bool
+	// ManMode is whether to navigate the formatted man page by headings, options and references.
+	ManMode *bool
 	// StatusLine indicates whether to hide the status line.
 	StatusLine *bool
 	// PromptConfig holds settings related to the command prompt.
@@ -102,6 +127,11 @@ func (g *General) SetCaption(caption string) {
 	g.Caption = &caption
 }
 
+// SetSyntax sets the language of the syntax highlighting.
+func (g *General) SetSyntax(syntax string) {
+	g.Syntax = &syntax
+}
+
 // SetMultiColorWords sets the multi-color words.
 func (g *General) SetMultiColorWords(words []string) {
 	copied := make([]string, len(words))
@@ -109,6 +139,41 @@ func (g *General) SetMultiColorWords(words []string) {
 	g.MultiColorWords = &copied
 }
 
+// SetColumnHide sets the columns to hide.
+func (g *General) SetColumnHide(columns []string) {
+	copied := make([]string, len(columns))
+	copy(copied, columns)
+	g.ColumnHide = &copied
+}
+
+// SetColumnOrder sets the columns to display first.
+func (g *General) SetColumnOrder(columns []string) {
+	copied := make([]string, len(columns))
+	copy(copied, columns)
+	g.ColumnOrder = &copied
+}
+
+// SetColumnTransform sets the display transforms of the columns.
+func (g *General) SetColumnTransform(transforms []string) {
+	copied := make([]string, len(transforms))
+	copy(copied, transforms)
+	g.ColumnTransform = &copied
+}
+
+// SetColumnNames sets the names of the columns.
+func (g *General) SetColumnNames(names []string) {
+	copied := make([]string, len(names))
+	copy(copied, names)
+	g.ColumnNames = &copied
+}
+
+// SetLogfmtKeys sets the logfmt keys to display as columns.
+func (g *General) SetLogfmtKeys(keys []string) {
+	copied := make([]string, len(keys))
+	copy(copied, keys)
+	g.LogfmtKeys = &copied
+}
+
 // SetColumnMode sets the column mode.
 func (g *General) SetColumnMode(mode bool) {
 	g.ColumnMode = &mode
@@ -139,6 +204,11 @@ func (g *General) SetColumnDelimiter(delim string) {
 	g.ColumnDelimiter = &delim
 }
 
+// SetColumnRegexp sets the regular expression that splits the columns.
+func (g *General) SetColumnRegexp(reg string) {
+	g.ColumnRegexp = &reg
+}
+
 // SetSectionDelimiter sets the section delimiter.
 func (g *General) SetSectionDelimiter(delim string) {
 	g.SectionDelimiter = &delim
@@ -263,3 +333,13 @@ func (g *General) SetSectionHeader(sectionHeader bool) {
 func (g *General) SetHideOtherSection(hide bool) {
 	g.HideOtherSection = &hide
 }
+
+// SetDiffMode sets whether to enable the diff mode.
+func (g *General) SetDiffMode(diff bool) {
+	g.DiffMode = &diff
+}
+
+// SetManMode sets whether to enable the man page mode.
+func (g *General) SetManMode(man bool) {
+	g.ManMode = &man
+}
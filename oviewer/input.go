@@ -64,6 +64,22 @@ const (
 	MarkNum
 	// StyleToggle is for toggling style highlight suppression.
 	StyleToggle
+	// JSONFields is for setting the JSON fields to extract into columns.
+	JSONFields
+	// LogfmtKeys is for setting the logfmt keys to extract into columns.
+	LogfmtKeys
+	// SortColumn is for setting the sort order of the cursor column.
+	SortColumn
+	// ColumnStatsFilter is for selecting the value of the column statistics to filter.
+	ColumnStatsFilter
+	// Export is for exporting the column values in a format.
+	Export
+	// FilterExpr is for filtering by the expression over the columns.
+	FilterExpr
+	// ColumnTransform is for setting the display transform of the cursor column.
+	ColumnTransform
+	// JumpOption is for moving to the definition of the option.
+	JumpOption
 )
 
 // Input represents the status of various inputs.
@@ -100,6 +116,14 @@ func NewInput() *Input {
 	i.Candidate[ConvertType] = converterCandidate()
 	i.Candidate[MarkNum] = blankCandidate()
 	i.Candidate[StyleToggle] = blankCandidate()
+	i.Candidate[JSONFields] = jsonFieldsCandidate()
+	i.Candidate[LogfmtKeys] = logfmtKeysCandidate()
+	i.Candidate[SortColumn] = sortColumnCandidate()
+	i.Candidate[ColumnStatsFilter] = blankCandidate()
+	i.Candidate[Export] = exportCandidate()
+	i.Candidate[FilterExpr] = blankCandidate()
+	i.Candidate[ColumnTransform] = columnTransformCandidate()
+	i.Candidate[JumpOption] = blankCandidate()
 
 	i.Event = &eventNormal{}
 	return &i
@@ -397,6 +421,15 @@ func (root *Root) toggleRegexpSearch(context.Context) {
 	root.setPromptOpt()
 }
 
+// toggleNormalizeSearch toggles normalized search.
+func (root *Root) toggleNormalizeSearch(context.Context) {
+	root.Config.NormalizeSearch = !root.Config.NormalizeSearch
+	if !root.Config.NormalizeSearch {
+		root.Config.FoldDiacritics = false
+	}
+	root.setPromptOpt()
+}
+
 func (root *Root) toggleNonMatch(context.Context) {
 	root.Doc.nonMatch = !root.Doc.nonMatch
 	root.setPromptOpt()
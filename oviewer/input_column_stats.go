@@ -0,0 +1,57 @@
+package oviewer
+
+import (
+	"context"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// inputColumnStatsFilter sets the inputMode to ColumnStatsFilter.
+func (root *Root) inputColumnStatsFilter(ctx context.Context) {
+	if root.sidebarMode != SidebarModeColumnStats {
+		root.openSidebar(ctx, SidebarModeColumnStats)
+	}
+	input := root.input
+	input.reset()
+	input.Event = newColumnStatsFilterEvent(input.Candidate[ColumnStatsFilter])
+}
+
+// eventColumnStatsFilter represents the column statistics filter input mode.
+type eventColumnStatsFilter struct {
+	tcell.EventTime
+	clist *candidate
+	value string
+}
+
+// newColumnStatsFilterEvent returns a new eventColumnStatsFilter with the given candidate list.
+func newColumnStatsFilterEvent(clist *candidate) *eventColumnStatsFilter {
+	return &eventColumnStatsFilter{clist: clist}
+}
+
+// Mode returns InputMode.
+func (*eventColumnStatsFilter) Mode() InputMode {
+	return ColumnStatsFilter
+}
+
+// Prompt returns the prompt string in the input field.
+func (*eventColumnStatsFilter) Prompt() string {
+	return "Filter value number:"
+}
+
+// Confirm returns the event when the input is confirmed.
+func (e *eventColumnStatsFilter) Confirm(str string) tcell.Event {
+	e.value = str
+	e.clist.toLast(str)
+	e.SetEventNow()
+	return e
+}
+
+// Up returns strings when the up key is pressed during input.
+func (e *eventColumnStatsFilter) Up(_ string) string {
+	return e.clist.up()
+}
+
+// Down returns strings when the down key is pressed during input.
+func (e *eventColumnStatsFilter) Down(_ string) string {
+	return e.clist.down()
+}
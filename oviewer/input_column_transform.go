@@ -0,0 +1,63 @@
+package oviewer
+
+import (
+	"context"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// inputColumnTransform sets the inputMode to ColumnTransform.
+func (root *Root) inputColumnTransform(context.Context) {
+	input := root.input
+	input.reset()
+	input.Event = newColumnTransformEvent(input.Candidate[ColumnTransform])
+}
+
+// columnTransformCandidate returns the candidate to set to default.
+func columnTransformCandidate() *candidate {
+	return &candidate{
+		list: append([]string{transformNone}, transformNames()...),
+	}
+}
+
+// eventColumnTransform represents the column transform input mode.
+type eventColumnTransform struct {
+	tcell.EventTime
+	clist *candidate
+	value string
+}
+
+// newColumnTransformEvent returns eventColumnTransform.
+func newColumnTransformEvent(clist *candidate) *eventColumnTransform {
+	return &eventColumnTransform{clist: clist}
+}
+
+// Mode returns InputMode.
+func (*eventColumnTransform) Mode() InputMode {
+	return ColumnTransform
+}
+
+// Prompt returns the prompt string in the input field.
+func (*eventColumnTransform) Prompt() string {
+	return "Transform:"
+}
+
+// Confirm returns the event when the input is confirmed.
+func (e *eventColumnTransform) Confirm(str string) tcell.Event {
+	e.value = str
+	if str != "" {
+		e.clist.toLast(str)
+	}
+	e.SetEventNow()
+	return e
+}
+
+// Up returns strings when the up key is pressed during input.
+func (e *eventColumnTransform) Up(_ string) string {
+	return e.clist.up()
+}
+
+// Down returns strings when the down key is pressed during input.
+func (e *eventColumnTransform) Down(_ string) string {
+	return e.clist.down()
+}
@@ -20,6 +20,9 @@ func converterCandidate() *candidate {
 			convEscaped,
 			convRaw,
 			convAlign,
+			convJSON,
+			convVT,
+			convMarkdown,
 		},
 	}
 }
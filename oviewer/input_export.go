@@ -0,0 +1,69 @@
+package oviewer
+
+import (
+	"context"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// inputExport sets the inputMode to Export.
+func (root *Root) inputExport(context.Context) {
+	input := root.input
+	input.reset()
+	input.Event = newExportEvent(input.Candidate[Export])
+}
+
+// exportCandidate returns the candidate to set to default.
+func exportCandidate() *candidate {
+	return &candidate{
+		list: []string{
+			"table",
+			"markdown",
+			"json",
+			"tsv",
+			"csv",
+		},
+	}
+}
+
+// eventExport represents the export input mode.
+type eventExport struct {
+	tcell.EventTime
+	clist *candidate
+	value string
+}
+
+// newExportEvent returns eventExport.
+func newExportEvent(clist *candidate) *eventExport {
+	return &eventExport{clist: clist}
+}
+
+// Mode returns InputMode.
+func (*eventExport) Mode() InputMode {
+	return Export
+}
+
+// Prompt returns the prompt string in the input field.
+func (*eventExport) Prompt() string {
+	return "(Export)format [file]:"
+}
+
+// Confirm returns the event when the input is confirmed.
+func (e *eventExport) Confirm(str string) tcell.Event {
+	e.value = str
+	if str != "" {
+		e.clist.toLast(str)
+	}
+	e.SetEventNow()
+	return e
+}
+
+// Up returns strings when the up key is pressed during input.
+func (e *eventExport) Up(_ string) string {
+	return e.clist.up()
+}
+
+// Down returns strings when the down key is pressed during input.
+func (e *eventExport) Down(_ string) string {
+	return e.clist.down()
+}
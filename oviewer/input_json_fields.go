@@ -0,0 +1,67 @@
+package oviewer
+
+import (
+	"context"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// inputJSONFields sets the inputMode to JSONFields.
+func (root *Root) inputJSONFields(context.Context) {
+	input := root.input
+	input.reset()
+	input.Event = newJSONFieldsEvent(input.Candidate[JSONFields])
+}
+
+// jsonFieldsCandidate returns the candidate to set to default.
+func jsonFieldsCandidate() *candidate {
+	return &candidate{
+		list: []string{
+			"time,level,msg",
+			"ts,level,msg",
+			"@timestamp,log.level,message",
+		},
+	}
+}
+
+// eventJSONFields represents the JSON fields input mode.
+type eventJSONFields struct {
+	tcell.EventTime
+	clist *candidate
+	value string
+}
+
+// newJSONFieldsEvent returns eventJSONFields.
+func newJSONFieldsEvent(clist *candidate) *eventJSONFields {
+	return &eventJSONFields{clist: clist}
+}
+
+// Mode returns InputMode.
+func (*eventJSONFields) Mode() InputMode {
+	return JSONFields
+}
+
+// Prompt returns the prompt string in the input field.
+func (*eventJSONFields) Prompt() string {
+	return "JSON fields:"
+}
+
+// Confirm returns the event when the input is confirmed.
+func (e *eventJSONFields) Confirm(str string) tcell.Event {
+	e.value = str
+	if str != "" {
+		e.clist.toLast(str)
+	}
+	e.SetEventNow()
+	return e
+}
+
+// Up returns strings when the up key is pressed during input.
+func (e *eventJSONFields) Up(_ string) string {
+	return e.clist.up()
+}
+
+// Down returns strings when the down key is pressed during input.
+func (e *eventJSONFields) Down(_ string) string {
+	return e.clist.down()
+}
@@ -0,0 +1,54 @@
+package oviewer
+
+import (
+	"context"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// inputJumpOption sets the inputMode to JumpOption.
+func (root *Root) inputJumpOption(context.Context) {
+	input := root.input
+	input.reset()
+	input.Event = newJumpOptionEvent(input.Candidate[JumpOption])
+}
+
+// eventJumpOption represents the jump option input mode.
+type eventJumpOption struct {
+	tcell.EventTime
+	clist *candidate
+	value string
+}
+
+// newJumpOptionEvent returns eventJumpOption.
+func newJumpOptionEvent(clist *candidate) *eventJumpOption {
+	return &eventJumpOption{clist: clist}
+}
+
+// Mode returns InputMode.
+func (*eventJumpOption) Mode() InputMode {
+	return JumpOption
+}
+
+// Prompt returns the prompt string in the input field.
+func (*eventJumpOption) Prompt() string {
+	return "Option:"
+}
+
+// Confirm returns the event when the input is confirmed.
+func (e *eventJumpOption) Confirm(str string) tcell.Event {
+	e.value = str
+	e.clist.toLast(str)
+	e.SetEventNow()
+	return e
+}
+
+// Up returns strings when the up key is pressed during input.
+func (e *eventJumpOption) Up(_ string) string {
+	return e.clist.up()
+}
+
+// Down returns strings when the down key is pressed during input.
+func (e *eventJumpOption) Down(_ string) string {
+	return e.clist.down()
+}
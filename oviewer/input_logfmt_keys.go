@@ -0,0 +1,67 @@
+package oviewer
+
+import (
+	"context"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// inputLogfmtKeys sets the inputMode to LogfmtKeys.
+func (root *Root) inputLogfmtKeys(context.Context) {
+	input := root.input
+	input.reset()
+	input.Event = newLogfmtKeysEvent(input.Candidate[LogfmtKeys])
+}
+
+// logfmtKeysCandidate returns the candidate to set to default.
+func logfmtKeysCandidate() *candidate {
+	return &candidate{
+		list: []string{
+			"time,level,msg",
+			"ts,level,msg",
+			"level,ts,caller,msg",
+		},
+	}
+}
+
+// eventLogfmtKeys represents the logfmt keys input mode.
+type eventLogfmtKeys struct {
+	tcell.EventTime
+	clist *candidate
+	value string
+}
+
+// newLogfmtKeysEvent returns eventLogfmtKeys.
+func newLogfmtKeysEvent(clist *candidate) *eventLogfmtKeys {
+	return &eventLogfmtKeys{clist: clist}
+}
+
+// Mode returns InputMode.
+func (*eventLogfmtKeys) Mode() InputMode {
+	return LogfmtKeys
+}
+
+// Prompt returns the prompt string in the input field.
+func (*eventLogfmtKeys) Prompt() string {
+	return "logfmt keys:"
+}
+
+// Confirm returns the event when the input is confirmed.
+func (e *eventLogfmtKeys) Confirm(str string) tcell.Event {
+	e.value = str
+	if str != "" {
+		e.clist.toLast(str)
+	}
+	e.SetEventNow()
+	return e
+}
+
+// Up returns strings when the up key is pressed during input.
+func (e *eventLogfmtKeys) Up(_ string) string {
+	return e.clist.up()
+}
+
+// Down returns strings when the down key is pressed during input.
+func (e *eventLogfmtKeys) Down(_ string) string {
+	return e.clist.down()
+}
@@ -69,6 +69,11 @@ func (root *Root) setPromptOpt() {
 	if root.Config.RegexpSearch {
 		opt.WriteString("(R)")
 	}
+	if root.Config.FoldDiacritics {
+		opt.WriteString("(N~)")
+	} else if root.Config.NormalizeSearch {
+		opt.WriteString("(N)")
+	}
 	if mode != Filter && mode != MarkByPattern && root.Config.Incsearch {
 		opt.WriteString("(I)")
 	}
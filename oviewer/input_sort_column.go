@@ -0,0 +1,70 @@
+package oviewer
+
+import (
+	"context"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// inputSortColumn sets the inputMode to SortColumn.
+func (root *Root) inputSortColumn(context.Context) {
+	input := root.input
+	input.reset()
+	input.Event = newSortColumnEvent(input.Candidate[SortColumn])
+}
+
+// sortColumnCandidate returns the candidate to set to default.
+func sortColumnCandidate() *candidate {
+	return &candidate{
+		list: []string{
+			"human desc",
+			"numeric desc",
+			"lexical desc",
+			"human",
+			"numeric",
+			"lexical",
+		},
+	}
+}
+
+// eventSortColumn represents the sort column input mode.
+type eventSortColumn struct {
+	tcell.EventTime
+	clist *candidate
+	value string
+}
+
+// newSortColumnEvent returns eventSortColumn.
+func newSortColumnEvent(clist *candidate) *eventSortColumn {
+	return &eventSortColumn{clist: clist}
+}
+
+// Mode returns InputMode.
+func (*eventSortColumn) Mode() InputMode {
+	return SortColumn
+}
+
+// Prompt returns the prompt string in the input field.
+func (*eventSortColumn) Prompt() string {
+	return "Sort order:"
+}
+
+// Confirm returns the event when the input is confirmed.
+func (e *eventSortColumn) Confirm(str string) tcell.Event {
+	e.value = str
+	if str != "" {
+		e.clist.toLast(str)
+	}
+	e.SetEventNow()
+	return e
+}
+
+// Up returns strings when the up key is pressed during input.
+func (e *eventSortColumn) Up(_ string) string {
+	return e.clist.up()
+}
+
+// Down returns strings when the down key is pressed during input.
+func (e *eventSortColumn) Down(_ string) string {
+	return e.clist.down()
+}
@@ -0,0 +1,111 @@
+package oviewer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// jsonColumns toggles between the JSON fields column document and the original document.
+func (root *Root) jsonColumns(ctx context.Context) {
+	if root.toggleColumnDocument(ctx) {
+		return
+	}
+	root.inputJSONFields(ctx)
+}
+
+// setJSONFields extracts the JSON fields into a new column document.
+func (root *Root) setJSONFields(ctx context.Context, input string) {
+	fields := parseColumnNames(input)
+	if len(fields) == 0 {
+		root.setMessage("no JSON fields")
+		return
+	}
+	header := func(context.Context) []string {
+		return fields
+	}
+	extract := func(line []byte) []string {
+		return jsonFieldValues(line, fields)
+	}
+	root.columnDocument(ctx, "json:"+strings.Join(fields, ","), header, extract)
+}
+
+// jsonFieldValues returns the values of the field paths in the JSON line.
+// Missing fields are empty strings.
+// It returns nil if the line is not a JSON object.
+func jsonFieldValues(line []byte, fields []string) []string {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 || line[0] != '{' {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	var obj any
+	if err := dec.Decode(&obj); err != nil {
+		return nil
+	}
+	values := make([]string, len(fields))
+	for i, field := range fields {
+		v, ok := jsonFieldValue(obj, field)
+		if !ok {
+			continue
+		}
+		values[i] = jsonValueString(v)
+	}
+	return values
+}
+
+// jsonFieldValue returns the value of the dot-separated field path.
+// Keys that contain dots take precedence over nested objects,
+// and numbers select elements of an array.
+func jsonFieldValue(v any, path string) (any, bool) {
+	switch t := v.(type) {
+	case map[string]any:
+		if value, ok := t[path]; ok {
+			return value, true
+		}
+		for i := range len(path) {
+			if path[i] != '.' {
+				continue
+			}
+			if value, ok := t[path[:i]]; ok {
+				if value, ok := jsonFieldValue(value, path[i+1:]); ok {
+					return value, true
+				}
+			}
+		}
+	case []any:
+		key, rest, _ := strings.Cut(path, ".")
+		n, err := strconv.Atoi(key)
+		if err != nil || n < 0 || n >= len(t) {
+			return nil, false
+		}
+		if rest == "" {
+			return t[n], true
+		}
+		return jsonFieldValue(t[n], rest)
+	}
+	return nil, false
+}
+
+// jsonValueString returns the string representation of the JSON value.
+// Objects and arrays are returned as compact JSON.
+func jsonValueString(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case json.Number:
+		return t.String()
+	case bool:
+		return strconv.FormatBool(t)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
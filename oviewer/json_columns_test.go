@@ -0,0 +1,175 @@
+package oviewer
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+func Test_jsonFieldValues(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		line   string
+		fields []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "top level",
+			args: args{line: `{"level":"info","msg":"start"}`, fields: []string{"level", "msg"}},
+			want: []string{"info", "start"},
+		},
+		{
+			name: "nested",
+			args: args{line: `{"req":{"id":"a1","ms":12}}`, fields: []string{"req.id", "req.ms"}},
+			want: []string{"a1", "12"},
+		},
+		{
+			name: "dotted key",
+			args: args{line: `{"log.level":"warn","log":{"level":"info"}}`, fields: []string{"log.level"}},
+			want: []string{"warn"},
+		},
+		{
+			name: "array index",
+			args: args{line: `{"tags":["db","timeout"]}`, fields: []string{"tags.1", "tags.2"}},
+			want: []string{"timeout", ""},
+		},
+		{
+			name: "missing",
+			args: args{line: `{"level":"info"}`, fields: []string{"msg", "level"}},
+			want: []string{"", "info"},
+		},
+		{
+			name: "literal and object",
+			args: args{line: `{"ok":true,"v":null,"o":{"a": 1}}`, fields: []string{"ok", "v", "o"}},
+			want: []string{"true", "", `{"a":1}`},
+		},
+		{
+			name: "large number",
+			args: args{line: `{"n":12345678901234567890}`, fields: []string{"n"}},
+			want: []string{"12345678901234567890"},
+		},
+		{
+			name: "not JSON",
+			args: args{line: `level=info msg=start`, fields: []string{"level"}},
+			want: nil,
+		},
+		{
+			name: "invalid JSON",
+			args: args{line: `{"level":}`, fields: []string{"level"}},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := jsonFieldValues([]byte(tt.args.line), tt.args.fields); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("jsonFieldValues() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseColumnNames(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "fields",
+			input: "ts,level,msg,req.id",
+			want:  []string{"ts", "level", "msg", "req.id"},
+		},
+		{
+			name:  "spaces and empty",
+			input: " ts , ,msg,",
+			want:  []string{"ts", "msg"},
+		},
+		{
+			name:  "empty",
+			input: "",
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := parseColumnNames(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseColumnNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoot_setJSONFields(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	tests := []struct {
+		name     string
+		input    string
+		wantDocs int
+		wantLine map[int]string
+	}{
+		{
+			name:     "fields",
+			input:    "ts,level,service,msg,req.id",
+			wantDocs: 2,
+			wantLine: map[int]string{
+				0: "ts\tlevel\tservice\tmsg\treq.id",
+				1: "2024-01-01T00:00:00Z\tinfo\tapi\tstart\ta1",
+				2: "2024-01-01T00:00:01Z\twarn\tapi\tslow\\trequest\ta2",
+				3: "\t\t\t\t",
+				4: "2024-01-01T00:00:02Z\terror\t\tfailed\t",
+			},
+		},
+		{
+			name:     "no fields",
+			input:    " , ",
+			wantDocs: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := rootFileReadHelper(t, filepath.Join(testdata, "log.jsonl"))
+			root.Doc.WaitEOF()
+			root.setJSONFields(context.Background(), tt.input)
+			if root.DocumentLen() != tt.wantDocs {
+				t.Fatalf("setJSONFields() documents = %v, want %v", root.DocumentLen(), tt.wantDocs)
+			}
+			if tt.wantDocs == 1 {
+				return
+			}
+			doc := root.DocList[len(root.DocList)-1]
+			doc.WaitEOF()
+			if doc.documentType != DocColumns || !doc.ColumnMode || doc.Header != 1 {
+				t.Errorf("setJSONFields() type = %v, column mode = %v, header = %v", doc.documentType, doc.ColumnMode, doc.Header)
+			}
+			for lN, want := range tt.wantLine {
+				line, err := doc.Line(lN)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(line) != want {
+					t.Errorf("setJSONFields() line %d = %q, want %q", lN, line, want)
+				}
+			}
+			if n, ok := doc.lineNumMap.LoadForward(4); !ok || n != 3 {
+				t.Errorf("setJSONFields() lineNumMap = %v, want 3", n)
+			}
+			root.jsonColumns(context.Background())
+			if root.Doc != doc.parent {
+				t.Errorf("jsonColumns() did not return to the parent document")
+			}
+		})
+	}
+}
@@ -30,6 +30,10 @@ const (
 	actionFollowAll     = "follow_all"
 	actionToggleMouse   = "toggle_mouse"
 	actionSaveBuffer    = "save_buffer"
+	actionNextLink      = "next_link"
+	actionPrevLink      = "previous_link"
+	actionOpenLink      = "open_link"
+	actionCopyLink      = "copy_link"
 
 	// Moving
 	actionMoveDown       = "down"
@@ -50,6 +54,7 @@ const (
 	actionMoveEndRight   = "end_right"
 	actionGoLine         = "goto"
 	actionMarkNumber     = "mark_number"
+	actionJumpOption     = "jump_option"
 	actionStyleToggle    = "style_toggle"
 
 	// Sidebar
@@ -58,6 +63,9 @@ const (
 	actionSidebarDocList  = "sidebar_doc_list"
 	actionSidebarSections = "sidebar_sections"
 	actionSidebarStyles   = "sidebar_styles"
+	actionSidebarStats    = "sidebar_column_stats"
+	actionSidebarRecord   = "sidebar_record"
+	actionSidebarLinks    = "sidebar_links"
 	actionSidebarUp       = "sidebar_up"
 	actionSidebarDown     = "sidebar_down"
 	actionSidebarLeft     = "sidebar_left"
@@ -95,8 +103,10 @@ const (
 	actionPlain       = "plain_mode"
 	actionAlignFormat = "align_format"
 	actionRawFormat   = "raw_format"
+	actionAutoDetect  = "auto_detect"
 	actionRuler       = "toggle_ruler"
 	actionStatusLine  = "status_line"
+	actionDiffMode    = "diff_mode"
 
 	// Change Display with Input
 	actionViewMode       = "set_view_mode"
@@ -111,9 +121,21 @@ const (
 	actionHeaderColumn   = "header_column"
 
 	// Column operation
-	actionFixedColumn  = "fixed_column"
-	actionShrinkColumn = "shrink_column"
-	actionRightAlign   = "right_align"
+	actionFixedColumn   = "fixed_column"
+	actionShrinkColumn  = "shrink_column"
+	actionRightAlign    = "right_align"
+	actionJSONColumns   = "json_columns"
+	actionLogfmtColumns = "logfmt_columns"
+	actionCSVRecords    = "csv_records"
+	actionSortColumn    = "sort_column"
+	actionHideColumn    = "hide_column"
+	actionUnhideColumns = "unhide_columns"
+	actionColumnLeft    = "move_column_left"
+	actionColumnRight   = "move_column_right"
+	actionStatsFilter   = "column_stats_filter"
+	actionExportTable   = "export_table"
+	actionFilterExpr    = "filter_expression"
+	actionTransform     = "column_transform"
 
 	// Section operation
 	actionSection       = "section_delimiter"
@@ -136,6 +158,7 @@ const (
 	inputSmartCaseSensitive = "input_smart_casesensitive"
 	inputIncSearch          = "input_incsearch"
 	inputRegexpSearch       = "input_regexp_search"
+	inputNormalizeSearch    = "input_normalize_search"
 	inputNonMatch           = "input_non_match"
 	inputPrevious           = "input_previous"
 	inputNext               = "input_next"
@@ -154,6 +177,10 @@ func (root *Root) handlers() map[string]func(context.Context) {
 		actionWriteOriginal: root.toggleWriteOriginal,
 		actionSuspend:       root.suspend,
 		actionEdit:          root.edit,
+		actionNextLink:      root.nextLink,
+		actionPrevLink:      root.prevLink,
+		actionOpenLink:      root.openLink,
+		actionCopyLink:      root.copyLink,
 		actionHelp:          root.helpDisplay,
 		actionLogDoc:        root.logDisplay,
 		actionSync:          root.ViewSync,
@@ -181,6 +208,7 @@ func (root *Root) handlers() map[string]func(context.Context) {
 		actionMoveEndRight:   root.moveEndRight,
 		actionGoLine:         root.inputGoLine,
 		actionMarkNumber:     root.inputMarkNumber,
+		actionJumpOption:     root.inputJumpOption,
 		actionStyleToggle:    root.inputStyleToggle,
 
 		// Sidebar
@@ -189,6 +217,9 @@ func (root *Root) handlers() map[string]func(context.Context) {
 		actionSidebarDocList:  root.toggleSidebarDocList,
 		actionSidebarSections: root.toggleSidebarSections,
 		actionSidebarStyles:   root.toggleSidebarStyles,
+		actionSidebarStats:    root.toggleSidebarColumnStats,
+		actionSidebarRecord:   root.toggleSidebarRecord,
+		actionSidebarLinks:    root.toggleSidebarLinks,
 		actionSidebarUp:       root.sidebarUp,
 		actionSidebarDown:     root.sidebarDown,
 		actionSidebarLeft:     root.sidebarLeft,
@@ -222,10 +253,12 @@ func (root *Root) handlers() map[string]func(context.Context) {
 		actionColumnWidth: root.toggleColumnWidth,
 		actionRainbow:     root.toggleRainbow,
 		actionAlternate:   root.toggleAlternateRows,
+		actionDiffMode:    root.toggleDiffMode,
 		actionLineNumMode: root.toggleLineNumMode,
 		actionPlain:       root.togglePlain,
 		actionAlignFormat: root.alignFormat,
 		actionRawFormat:   root.rawFormat,
+		actionAutoDetect:  root.toggleDetectFormat,
 		actionRuler:       root.toggleRuler,
 		actionStatusLine:  root.toggleStatusLine,
 
@@ -242,9 +275,21 @@ func (root *Root) handlers() map[string]func(context.Context) {
 		actionHeaderColumn:   root.inputHeaderColumn,
 
 		// Column operation
-		actionFixedColumn:  root.toggleFixedColumn,
-		actionShrinkColumn: root.toggleShrinkColumn,
-		actionRightAlign:   root.toggleRightAlign,
+		actionFixedColumn:   root.toggleFixedColumn,
+		actionShrinkColumn:  root.toggleShrinkColumn,
+		actionRightAlign:    root.toggleRightAlign,
+		actionJSONColumns:   root.jsonColumns,
+		actionLogfmtColumns: root.logfmtColumns,
+		actionCSVRecords:    root.csvRecords,
+		actionSortColumn:    root.inputSortColumn,
+		actionHideColumn:    root.hideColumn,
+		actionUnhideColumns: root.unhideColumns,
+		actionColumnLeft:    root.moveColumnOrderLeft,
+		actionColumnRight:   root.moveColumnOrderRight,
+		actionStatsFilter:   root.inputColumnStatsFilter,
+		actionExportTable:   root.inputExport,
+		actionFilterExpr:    root.inputFilterExpr,
+		actionTransform:     root.inputColumnTransform,
 
 		// Section operation
 		actionSection:       root.inputSectionDelimiter,
@@ -266,6 +311,7 @@ func (root *Root) handlers() map[string]func(context.Context) {
 		inputCaseSensitive:      root.toggleCaseSensitive,
 		inputSmartCaseSensitive: root.toggleSmartCaseSensitive,
 		inputRegexpSearch:       root.toggleRegexpSearch,
+		inputNormalizeSearch:    root.toggleNormalizeSearch,
 		inputIncSearch:          root.toggleIncSearch,
 		inputNonMatch:           root.toggleNonMatch,
 		inputPrevious:           root.candidatePrevious,
@@ -359,6 +405,10 @@ var keyBindDescriptions = []KeyBindDescription{
 	{Group: GroupGeneral, Action: actionWriteOriginal, Description: "toggle writing original content (instead of current screen) on exit"},
 	{Group: GroupGeneral, Action: actionSuspend, Description: "suspend"},
 	{Group: GroupGeneral, Action: actionEdit, Description: "edit current document"},
+	{Group: GroupGeneral, Action: actionNextLink, Description: "move to next link"},
+	{Group: GroupGeneral, Action: actionPrevLink, Description: "move to previous link"},
+	{Group: GroupGeneral, Action: actionOpenLink, Description: "open current link"},
+	{Group: GroupGeneral, Action: actionCopyLink, Description: "copy current link to clipboard"},
 	{Group: GroupGeneral, Action: actionHelp, Description: "display help screen"},
 	{Group: GroupGeneral, Action: actionLogDoc, Description: "display log screen"},
 	{Group: GroupGeneral, Action: actionSync, Description: "redraw the screen"},
@@ -386,6 +436,7 @@ var keyBindDescriptions = []KeyBindDescription{
 	{Group: GroupMoving, Action: actionMoveEndRight, Description: "go to end of line"},
 	{Group: GroupMoving, Action: actionGoLine, Description: "go to line (number, `.n`, or `n%`)"},
 	{Group: GroupMoving, Action: actionMarkNumber, Description: "go to mark number"},
+	{Group: GroupMoving, Action: actionJumpOption, Description: "go to definition of option (`-x` or `--flag`)"},
 
 	// Sidebar.
 	{Group: GroupSidebar, Action: actionSidebarHelp, Description: "toggle help in sidebar"},
@@ -393,6 +444,9 @@ var keyBindDescriptions = []KeyBindDescription{
 	{Group: GroupSidebar, Action: actionSidebarDocList, Description: "toggle document list in sidebar"},
 	{Group: GroupSidebar, Action: actionSidebarSections, Description: "toggle section list in sidebar"},
 	{Group: GroupSidebar, Action: actionSidebarStyles, Description: "toggle style usage list in sidebar"},
+	{Group: GroupSidebar, Action: actionSidebarStats, Description: "toggle cursor column statistics in sidebar"},
+	{Group: GroupSidebar, Action: actionSidebarRecord, Description: "toggle record view of the current row in sidebar"},
+	{Group: GroupSidebar, Action: actionSidebarLinks, Description: "toggle link list in sidebar"},
 	{Group: GroupSidebar, Action: actionSidebarUp, Description: "scroll up in sidebar"},
 	{Group: GroupSidebar, Action: actionSidebarDown, Description: "scroll down in sidebar"},
 	{Group: GroupSidebar, Action: actionSidebarLeft, Description: "scroll left in sidebar"},
@@ -430,8 +484,10 @@ var keyBindDescriptions = []KeyBindDescription{
 	{Group: GroupChange, Action: actionPlain, Description: "toggle plain mode (strip ANSI styles)"},
 	{Group: GroupChange, Action: actionAlignFormat, Description: "align columns"},
 	{Group: GroupChange, Action: actionRawFormat, Description: "toggle raw output mode"},
+	{Group: GroupChange, Action: actionAutoDetect, Description: "detected format toggle"},
 	{Group: GroupChange, Action: actionRuler, Description: "ruler toggle"},
 	{Group: GroupChange, Action: actionStatusLine, Description: "status line toggle"},
+	{Group: GroupChange, Action: actionDiffMode, Description: "diff mode toggle"},
 	{Group: GroupChange, Action: actionStyleToggle, Description: "suppress style highlight by number"},
 
 	// Change display with input.
@@ -450,6 +506,18 @@ var keyBindDescriptions = []KeyBindDescription{
 	{Group: GroupColumn, Action: actionFixedColumn, Description: "toggle fixed header column"},
 	{Group: GroupColumn, Action: actionShrinkColumn, Description: "shrink column toggle (align mode only)"},
 	{Group: GroupColumn, Action: actionRightAlign, Description: "right align column toggle (align mode only)"},
+	{Group: GroupColumn, Action: actionJSONColumns, Description: "JSON fields to columns toggle"},
+	{Group: GroupColumn, Action: actionLogfmtColumns, Description: "logfmt keys to columns toggle"},
+	{Group: GroupColumn, Action: actionCSVRecords, Description: "unquoted CSV records toggle"},
+	{Group: GroupColumn, Action: actionSortColumn, Description: "sort by the cursor column"},
+	{Group: GroupColumn, Action: actionHideColumn, Description: "hide the cursor column (align mode only)"},
+	{Group: GroupColumn, Action: actionUnhideColumns, Description: "show all hidden columns"},
+	{Group: GroupColumn, Action: actionColumnLeft, Description: "move the cursor column left (align mode only)"},
+	{Group: GroupColumn, Action: actionColumnRight, Description: "move the cursor column right (align mode only)"},
+	{Group: GroupColumn, Action: actionStatsFilter, Description: "filter by a frequent value of the cursor column"},
+	{Group: GroupColumn, Action: actionExportTable, Description: "export columns as CSV/TSV/JSON/table"},
+	{Group: GroupColumn, Action: actionFilterExpr, Description: "filter by an expression over the columns"},
+	{Group: GroupColumn, Action: actionTransform, Description: "display transform of the cursor column (align mode only)"},
 
 	// Section operation.
 	{Group: GroupSection, Action: actionSection, Description: "section delimiter regular expression"},
@@ -471,6 +539,7 @@ var keyBindDescriptions = []KeyBindDescription{
 	{Group: GroupTyping, Action: inputCaseSensitive, Description: "case-sensitive toggle"},
 	{Group: GroupTyping, Action: inputSmartCaseSensitive, Description: "smart case-sensitive toggle"},
 	{Group: GroupTyping, Action: inputRegexpSearch, Description: "regular expression search toggle"},
+	{Group: GroupTyping, Action: inputNormalizeSearch, Description: "normalized (width/accent-insensitive) search toggle"},
 	{Group: GroupTyping, Action: inputIncSearch, Description: "incremental search toggle"},
 	{Group: GroupTyping, Action: inputNonMatch, Description: "toggle non-match filter"},
 	{Group: GroupTyping, Action: inputPrevious, Description: "previous candidate"},
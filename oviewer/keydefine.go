@@ -12,6 +12,10 @@ func DefaultKeyBinds() KeyBind {
 		actionWriteExit:      {"Q"},
 		actionSuspend:        {"ctrl+z"},
 		actionEdit:           {"alt+v"},
+		actionNextLink:       {"ctrl+alt+n"},
+		actionPrevLink:       {"ctrl+alt+p"},
+		actionOpenLink:       {"ctrl+alt+o"},
+		actionCopyLink:       {"ctrl+alt+y"},
 		actionSync:           {"ctrl+l"},
 		actionFollow:         {"ctrl+f"},
 		actionFollowAll:      {"ctrl+a"},
@@ -27,6 +31,7 @@ func DefaultKeyBinds() KeyBind {
 		actionRemoveMark:     {"M"},
 		actionRemoveAllMark:  {"ctrl+delete"},
 		actionAlternate:      {"C"},
+		actionDiffMode:       {"D"},
 		actionLineNumMode:    {"G"},
 		actionWrap:           {"w", "W"},
 		actionWordWrap:       {"alt+w"},
@@ -42,9 +47,22 @@ func DefaultKeyBinds() KeyBind {
 		actionHideOther:      {"alt+-"},
 		actionAlignFormat:    {"alt+f"},
 		actionRawFormat:      {"alt+r"},
+		actionAutoDetect:     {"alt+e"},
 		actionFixedColumn:    {"F"},
 		actionShrinkColumn:   {"s"},
 		actionRightAlign:     {"alt+a"},
+		actionJSONColumns:    {"alt+j"},
+		actionLogfmtColumns:  {"alt+g"},
+		actionCSVRecords:     {"alt+q"},
+		actionSortColumn:     {"O"},
+		actionHideColumn:     {"X"},
+		actionUnhideColumns:  {"ctrl+alt+x"},
+		actionColumnLeft:     {"alt+shift+Left"},
+		actionColumnRight:    {"alt+shift+Right"},
+		actionStatsFilter:    {"V"},
+		actionExportTable:    {"E"},
+		actionFilterExpr:     {"alt+p"},
+		actionTransform:      {"U"},
 		actionRuler:          {"alt+shift+F9"},
 		actionWriteOriginal:  {"alt+shift+F8"},
 		actionStatusLine:     {"ctrl+F10"},
@@ -78,6 +96,9 @@ func DefaultKeyBinds() KeyBind {
 		actionSidebarDocList:  {"alt+l"},
 		actionSidebarSections: {"alt+u"},
 		actionSidebarStyles:   {"alt+y"},
+		actionSidebarStats:    {"alt+z"},
+		actionSidebarRecord:   {"alt+b"},
+		actionSidebarLinks:    {"L"},
 		actionSidebarUp:       {"shift+Up"},
 		actionSidebarDown:     {"shift+Down"},
 		actionSidebarLeft:     {"shift+Left"},
@@ -90,6 +111,7 @@ func DefaultKeyBinds() KeyBind {
 		actionHeaderColumn:   {"Y"},
 		actionHeader:         {"H"},
 		actionJumpTarget:     {"j"},
+		actionJumpOption:     {"-"},
 		actionMultiColor:     {"."},
 		actionSaveBuffer:     {"S"},
 		actionSearch:         {"/"},
@@ -113,6 +135,7 @@ func DefaultKeyBinds() KeyBind {
 		inputSmartCaseSensitive: {"alt+s"},
 		inputIncSearch:          {"alt+i"},
 		inputRegexpSearch:       {"alt+r"},
+		inputNormalizeSearch:    {"alt+n"},
 		inputNonMatch:           {"!"},
 		inputPrevious:           {"Up"},
 		inputNext:               {"Down"},
@@ -160,6 +183,7 @@ func LessKeyBinds() KeyBind {
 		actionGoLine:         {":"},
 		actionHeaderColumn:   {"ctrl+alt+d"},
 		actionJumpTarget:     {"alt+j"},
+		actionJSONColumns:    {"ctrl+alt+j"},
 		actionSaveBuffer:     {"s"},
 		actionConvertType:    {"ctrl+alt+t"},
 		actionVerticalHeader: {"ctrl+alt+b"},
@@ -0,0 +1,479 @@
+package oviewer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v3"
+	"github.com/google/shlex"
+)
+
+// DefaultLinkOpener is the fallback command to open links if no other command is specified.
+var DefaultLinkOpener string
+
+func init() {
+	switch runtime.GOOS {
+	case "darwin":
+		DefaultLinkOpener = "open"
+	case "windows":
+		DefaultLinkOpener = "rundll32 url.dll,FileProtocolHandler"
+	default:
+		DefaultLinkOpener = "xdg-open"
+	}
+}
+
+// linkItem is a link in the document.
+type linkItem struct {
+	// lN is the line number of the document where the link is.
+	lN int
+	// url is the destination of the link, or the path of the file:line link.
+	url string
+	// line is the line number of the file:line link. It is 0 for the URL.
+	line int
+	// man is true for the reference to the man page such as grep(1).
+	man bool
+	// start and end are the positions of the link in the line contents.
+	start int
+	end   int
+}
+
+// String returns the link as displayed.
+func (l linkItem) String() string {
+	if l.line > 0 {
+		return l.url + ":" + strconv.Itoa(l.line)
+	}
+	return l.url
+}
+
+// urlReg matches the plain text URL.
+// The punctuation at the end is not part of the URL.
+var urlReg = regexp.MustCompile("https?://[^\\s<>\"'`]*[^\\s<>\"'`.,;:!?)\\]}]")
+
+// fileLineReg matches the path with the line number such as "main.go:12".
+var fileLineReg = regexp.MustCompile(`(?:^|[\s(\["'=])((?:[\w.~+-]*/)*[\w.+-]*\.[A-Za-z]\w*):(\d+)`)
+
+// linkScanner finds the links with the settings of the document taken when it is created,
+// so that the links can be collected outside of the event loop.
+type linkScanner struct {
+	// fileName is the file name of the document to resolve the relative paths.
+	fileName string
+	tabWidth int
+	manMode  bool
+}
+
+// linkScanner returns the linkScanner with the current settings of the document.
+func (m *Document) linkScanner() linkScanner {
+	return linkScanner{
+		fileName: m.FileName,
+		tabWidth: m.TabWidth,
+		manMode:  m.ManMode,
+	}
+}
+
+// lineLinks returns the links in the line contents.
+func (m *Document) lineLinks(lN int, lc contents) []linkItem {
+	return m.linkScanner().lineLinks(lN, lc)
+}
+
+// lineLinks returns the links in the line contents.
+// The hyperlinks of OSC 8 come first, followed by the URLs and the paths of existing files with line numbers.
+// In the man page mode, the references to the other man pages follow.
+func (s linkScanner) lineLinks(lN int, lc contents) []linkItem {
+	var links []linkItem
+	prev := ""
+	for x, c := range lc {
+		_, url := c.style.GetUrl()
+		if url == "" {
+			prev = ""
+			continue
+		}
+		if url == prev {
+			links[len(links)-1].end = x + 1
+			continue
+		}
+		links = append(links, linkItem{lN: lN, url: url, start: x, end: x + 1})
+		prev = url
+	}
+
+	str, pos := ContentsToStr(lc)
+	for _, idx := range urlReg.FindAllStringIndex(str, -1) {
+		start, end := pos.x(idx[0]), pos.x(idx[1])
+		if containsLink(links, start) {
+			continue
+		}
+		links = append(links, linkItem{lN: lN, url: str[idx[0]:idx[1]], start: start, end: end})
+	}
+	for _, idx := range fileLineReg.FindAllStringSubmatchIndex(str, -1) {
+		start, end := pos.x(idx[2]), pos.x(idx[5])
+		if containsLink(links, start) {
+			continue
+		}
+		path, ok := s.filePath(str[idx[2]:idx[3]])
+		if !ok {
+			continue
+		}
+		line, err := strconv.Atoi(str[idx[4]:idx[5]])
+		if err != nil || line <= 0 {
+			continue
+		}
+		links = append(links, linkItem{lN: lN, url: path, line: line, start: start, end: end})
+	}
+	if s.manMode {
+		links = manRefLinks(lN, str, pos, links)
+	}
+	return links
+}
+
+// containsLink returns true if the position is in one of the links.
+func containsLink(links []linkItem, x int) bool {
+	for _, link := range links {
+		if x >= link.start && x < link.end {
+			return true
+		}
+	}
+	return false
+}
+
+// filePath returns the path of the existing file of the file:line link.
+// The relative path is resolved from the current directory, then from the directory of the document.
+func (s linkScanner) filePath(path string) (string, bool) {
+	candidates := []string{path}
+	if !filepath.IsAbs(path) && s.fileName != "" {
+		candidates = append(candidates, filepath.Join(filepath.Dir(s.fileName), path))
+	}
+	for _, p := range candidates {
+		if fi, err := os.Stat(p); err == nil && fi.Mode().IsRegular() {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// collectLinks returns the links in the lines from start to end.
+func (m *Document) collectLinks(ctx context.Context, s linkScanner, start int, end int) ([]linkItem, error) {
+	var links []linkItem
+	err := m.eachLine(ctx, start, func(lN int, line []byte) bool {
+		if lN >= end {
+			return false
+		}
+		lc := StrToContents(string(line), s.tabWidth)
+		links = append(links, s.lineLinks(lN, lc)...)
+		return true
+	})
+	return links, err
+}
+
+// updateLinks starts collecting the links of the document in the background if the document has been updated.
+// Only the lines added since the last collection are scanned,
+// and the whole document is scanned again if it has been reloaded.
+func (root *Root) updateLinks(ctx context.Context) {
+	m := root.Doc
+	end := m.BufEndNum()
+	if m.linksCollecting || (m.links != nil && m.linksEnd == end) {
+		return
+	}
+	start := m.linksEnd
+	if m.links == nil || start > end {
+		start = 0
+	}
+	m.linksCollecting = true
+	scanner := m.linkScanner()
+	go func() {
+		links, err := m.collectLinks(ctx, scanner, start, end)
+		if err != nil {
+			log.Printf("failed to collect links: %v\n", err)
+		}
+		root.sendAddLinks(m, start, end, links)
+	}()
+}
+
+// eventAddLinks represents the event to add the collected links to the document.
+type eventAddLinks struct {
+	m     *Document
+	links []linkItem
+	start int
+	end   int
+	tcell.EventTime
+}
+
+// sendAddLinks fires the eventAddLinks event.
+func (root *Root) sendAddLinks(m *Document, start int, end int, links []linkItem) {
+	ev := &eventAddLinks{}
+	ev.m = m
+	ev.links = links
+	ev.start = start
+	ev.end = end
+	ev.SetEventNow()
+	root.postEvent(ev)
+}
+
+// addLinks adds the links collected from the lines from start to end.
+func (root *Root) addLinks(m *Document, start int, end int, links []linkItem) {
+	m.linksCollecting = false
+	if start == 0 {
+		m.links = make([]linkItem, 0, len(links))
+		m.linkPoint = -1
+	}
+	m.links = append(m.links, links...)
+	m.linksEnd = end
+	if m == root.Doc && m.linkPoint < 0 && len(m.links) > 0 {
+		root.setMessagef("%d links", len(m.links))
+	}
+}
+
+// linksReady returns true if the links have been collected.
+// It displays a message while the links are being collected.
+func (root *Root) linksReady(ctx context.Context) bool {
+	root.updateLinks(ctx)
+	m := root.Doc
+	if m.links == nil {
+		root.setMessage("collecting links...")
+		return false
+	}
+	if len(m.links) == 0 {
+		root.setMessage("no links")
+		return false
+	}
+	return true
+}
+
+// firstLinkIndex returns the index of the first link at or after the top line of the screen.
+func (root *Root) firstLinkIndex() int {
+	top := root.Doc.topLN + root.Doc.firstLine()
+	for i, link := range root.Doc.links {
+		if link.lN >= top {
+			return i
+		}
+	}
+	return 0
+}
+
+// nextLink moves to the next link.
+func (root *Root) nextLink(ctx context.Context) {
+	if !root.linksReady(ctx) {
+		return
+	}
+	m := root.Doc
+	switch {
+	case m.linkPoint < 0:
+		m.linkPoint = root.firstLinkIndex()
+	case m.linkPoint+1 < len(m.links):
+		m.linkPoint++
+	default:
+		m.linkPoint = 0
+	}
+	root.moveToLink()
+}
+
+// prevLink moves to the previous link.
+func (root *Root) prevLink(ctx context.Context) {
+	if !root.linksReady(ctx) {
+		return
+	}
+	m := root.Doc
+	switch {
+	case m.linkPoint < 0:
+		m.linkPoint = max(root.firstLinkIndex()-1, 0)
+	case m.linkPoint > 0:
+		m.linkPoint--
+	default:
+		m.linkPoint = len(m.links) - 1
+	}
+	root.moveToLink()
+}
+
+// moveToLink moves to the line of the current link and displays the link.
+func (root *Root) moveToLink() {
+	m := root.Doc
+	link := m.links[m.linkPoint]
+	root.resetSelect()
+	m.moveLine(link.lN - m.firstLine())
+	root.setMessagef("link %d/%d: %s", m.linkPoint+1, len(m.links), link)
+}
+
+// currentLink returns the current link.
+// If no link is selected, the first link on the screen is returned.
+func (root *Root) currentLink(ctx context.Context) (linkItem, bool) {
+	if !root.linksReady(ctx) {
+		return linkItem{}, false
+	}
+	m := root.Doc
+	if m.linkPoint < 0 || m.linkPoint >= len(m.links) {
+		m.linkPoint = root.firstLinkIndex()
+	}
+	return m.links[m.linkPoint], true
+}
+
+// openLink opens the current link.
+func (root *Root) openLink(ctx context.Context) {
+	link, ok := root.currentLink(ctx)
+	if !ok {
+		return
+	}
+	root.openLinkItem(link)
+}
+
+// copyLink copies the current link to the clipboard.
+func (root *Root) copyLink(ctx context.Context) {
+	link, ok := root.currentLink(ctx)
+	if !ok {
+		return
+	}
+	root.copyClipboard(link.String())
+	root.setMessagef("copied link: %s", link)
+}
+
+// linkAt returns the link at the position of the screen.
+func (root *Root) linkAt(x int, y int) (linkItem, bool) {
+	m := root.Doc
+	if y < 0 || y >= len(root.scr.numbers) || x < m.bodyStartX {
+		return linkItem{}, false
+	}
+	number := root.scr.numbers[y]
+	lineC, ok := root.scr.lines[number.number]
+	if !ok || !lineC.valid {
+		return linkItem{}, false
+	}
+	lX := m.scrollX + (x - m.bodyStartX) + branchWidth(lineC.lc, number.wrap, root.scr.vWidth, m.bodyStartX)
+	for _, link := range m.lineLinks(number.number, lineC.lc) {
+		if lX >= link.start && lX < link.end {
+			return link, true
+		}
+	}
+	return linkItem{}, false
+}
+
+// openLinkAt opens the link at the position of the screen.
+func (root *Root) openLinkAt(x int, y int) bool {
+	link, ok := root.linkAt(x, y)
+	if !ok {
+		return false
+	}
+	root.openLinkItem(link)
+	return true
+}
+
+// openLinkItem opens the URL with the link opener, or the file:line link with the editor.
+// The reference to the man page is opened as a new document.
+func (root *Root) openLinkItem(link linkItem) {
+	if root.Config.DisableLinkOpen {
+		root.setMessage("opening links is disabled")
+		return
+	}
+	if link.man {
+		if err := root.openManPage(link.url); err != nil {
+			root.setMessageLog(err.Error())
+		}
+		return
+	}
+	if link.line > 0 {
+		if !root.Config.LinkOpenFile {
+			root.setMessagef("refused to open '%s' (LinkOpenFile is disabled)", link.url)
+			return
+		}
+		if err := root.runEditor(link.url, strconv.Itoa(link.line)); err != nil {
+			root.setMessageLog(err.Error())
+		}
+		return
+	}
+	if err := root.runLinkOpener(link.url); err != nil {
+		root.setMessageLog(err.Error())
+		return
+	}
+	root.setMessagef("open %s", link.url)
+}
+
+// linkOpenSchemes are the schemes of the URLs passed to the link opener.
+var linkOpenSchemes = []string{"http", "https"}
+
+// linkFileScheme is the scheme of the file URL, which is opened only if LinkOpenFile is enabled.
+const linkFileScheme = "file"
+
+// checkLinkURL returns an error if the URL should not be passed to the link opener.
+// The URL starting with "-" is refused so that it is not taken as an option of the command.
+// The file URL is refused unless openFile is true.
+func checkLinkURL(link string, openFile bool) error {
+	if strings.HasPrefix(link, "-") {
+		return fmt.Errorf("refused to open '%s'", link)
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return fmt.Errorf("invalid link '%s': %w", link, err)
+	}
+	for _, scheme := range linkOpenSchemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return nil
+		}
+	}
+	if openFile && strings.EqualFold(u.Scheme, linkFileScheme) {
+		return nil
+	}
+	return fmt.Errorf("refused to open the scheme '%s'", u.Scheme)
+}
+
+// runLinkOpener starts the link opener command with the URL without waiting for it.
+// Only the http and https URLs are opened, and the file URLs if LinkOpenFile is enabled.
+func (root *Root) runLinkOpener(url string) error {
+	if err := checkLinkURL(url, root.Config.LinkOpenFile); err != nil {
+		return err
+	}
+	opener := root.Config.LinkOpener
+	if opener == "" {
+		opener = DefaultLinkOpener
+	}
+	args, err := shlex.Split(opener)
+	if err != nil || len(args) == 0 {
+		return fmt.Errorf("invalid link opener command '%s'", opener)
+	}
+	args = append(args, url)
+	log.Println("Opening link with command:", args)
+	c := exec.Command(args[0], args[1:]...)
+	if err := c.Start(); err != nil {
+		return fmt.Errorf("failed to run link opener '%s': %w", opener, err)
+	}
+	go func() {
+		if err := c.Wait(); err != nil {
+			log.Printf("link opener '%s': %v\n", opener, err)
+		}
+	}()
+	return nil
+}
+
+// sidebarItemsForLinks creates SidebarItems for the link list sidebar.
+func (root *Root) sidebarItemsForLinks() []SidebarItem {
+	m := root.Doc
+	if m.links == nil {
+		return nil
+	}
+	var items []SidebarItem
+	current := m.linkPoint
+	root.adjustSidebarScroll(SidebarModeLinks, len(m.links), max(current, 0))
+	scroll := root.sidebarScrolls[SidebarModeLinks]
+	start := scroll.y
+	end := min(start+root.scr.vHeight, len(m.links))
+	for i := start; i < end; i++ {
+		link := m.links[i]
+		contents := StrToContents(fmt.Sprintf("%d %s", link.lN+1, link), 0)
+		items = append(items, SidebarItem{
+			Label:     fmt.Sprintf("%2d ", i),
+			Contents:  contents,
+			IsCurrent: i == current,
+		})
+	}
+	return items
+}
+
+// toggleSidebarLinks toggles the link list in the sidebar.
+func (root *Root) toggleSidebarLinks(ctx context.Context) {
+	root.updateLinks(ctx)
+	root.toggleSidebar(ctx, SidebarModeLinks)
+}
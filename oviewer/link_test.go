@@ -0,0 +1,198 @@
+package oviewer
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+func TestDocument_lineLinks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		str  string
+		want []linkItem
+	}{
+		{
+			name: "no links",
+			str:  "no links here",
+			want: nil,
+		},
+		{
+			name: "url",
+			str:  "see https://example.com/path, for details",
+			want: []linkItem{
+				{url: "https://example.com/path", start: 4, end: 28},
+			},
+		},
+		{
+			name: "osc8",
+			str:  "\x1b]8;;https://example.com/\x1b\\link\x1b]8;;\x1b\\ text",
+			want: []linkItem{
+				{url: "https://example.com/", start: 0, end: 4},
+			},
+		},
+		{
+			name: "osc8 and url",
+			str:  "\x1b]8;;https://example.com/\x1b\\https://example.com/\x1b]8;;\x1b\\ http://example.org",
+			want: []linkItem{
+				{url: "https://example.com/", start: 0, end: 20},
+				{url: "http://example.org", start: 21, end: 39},
+			},
+		},
+		{
+			name: "file line",
+			str:  "error at " + filepath.Join(testdata, "normal.txt") + ":3: failed",
+			want: []linkItem{
+				{url: filepath.Join(testdata, "normal.txt"), line: 3, start: 9, end: 9 + len(filepath.Join(testdata, "normal.txt")) + 2},
+			},
+		},
+		{
+			name: "not exist file",
+			str:  "error at notexist.txt:3: failed",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := docHelper(t, "")
+			lc := StrToContents(tt.str, 8)
+			got := m.lineLinks(0, lc)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lineLinks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkItem_String(t *testing.T) {
+	t.Parallel()
+	if got := (linkItem{url: "https://example.com"}).String(); got != "https://example.com" {
+		t.Errorf("String() = %q, want %q", got, "https://example.com")
+	}
+	if got := (linkItem{url: "main.go", line: 12}).String(); got != "main.go:12" {
+		t.Errorf("String() = %q, want %q", got, "main.go:12")
+	}
+}
+
+func TestRoot_nextLink(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootHelper(t)
+	root.Doc = docHelper(t, "a\nhttps://example.com/1\nb\nc\nhttps://example.com/2\n")
+	root.prepareScreen()
+	ctx := context.Background()
+
+	root.nextLink(ctx)
+	if root.message != "collecting links..." {
+		t.Errorf("message = %q, want %q", root.message, "collecting links...")
+	}
+	m := root.Doc
+	end := m.BufEndNum()
+	links, err := m.collectLinks(ctx, m.linkScanner(), 0, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root.addLinks(m, 0, end, links)
+
+	tests := []struct {
+		name     string
+		next     bool
+		wantLN   int
+		wantLink string
+	}{
+		{name: "next1", next: true, wantLN: 1, wantLink: "https://example.com/1"},
+		{name: "next2", next: true, wantLN: 4, wantLink: "https://example.com/2"},
+		{name: "next wrap", next: true, wantLN: 1, wantLink: "https://example.com/1"},
+		{name: "prev wrap", next: false, wantLN: 4, wantLink: "https://example.com/2"},
+	}
+	for _, tt := range tests {
+		if tt.next {
+			root.nextLink(ctx)
+		} else {
+			root.prevLink(ctx)
+		}
+		if root.Doc.topLN != tt.wantLN {
+			t.Errorf("%s: topLN = %d, want %d", tt.name, root.Doc.topLN, tt.wantLN)
+		}
+		link, ok := root.currentLink(ctx)
+		if !ok || link.url != tt.wantLink {
+			t.Errorf("%s: currentLink() = %v, want %s", tt.name, link, tt.wantLink)
+		}
+	}
+}
+
+func TestRoot_openLinkItem_disabled(t *testing.T) {
+	root := rootHelper(t)
+	root.Config.DisableLinkOpen = true
+	root.Config.LinkOpener = "false"
+	root.openLinkItem(linkItem{url: "https://example.com"})
+	if root.message != "opening links is disabled" {
+		t.Errorf("message = %q, want %q", root.message, "opening links is disabled")
+	}
+}
+
+func TestRoot_openLinkItem_file(t *testing.T) {
+	root := rootHelper(t)
+	root.Config.Editor = "false"
+	root.openLinkItem(linkItem{url: "main.go", line: 12})
+	want := "refused to open 'main.go' (LinkOpenFile is disabled)"
+	if root.message != want {
+		t.Errorf("message = %q, want %q", root.message, want)
+	}
+}
+
+func TestRoot_addLinks(t *testing.T) {
+	root := rootHelper(t)
+	m := root.Doc
+	root.addLinks(m, 0, 2, []linkItem{{url: "https://example.com/1", lN: 1}})
+	root.addLinks(m, 2, 4, []linkItem{{url: "https://example.com/2", lN: 3}})
+	if len(m.links) != 2 || m.linksEnd != 4 {
+		t.Errorf("links = %v, linksEnd = %d, want 2 links and 4", m.links, m.linksEnd)
+	}
+	// Scanning from 0 replaces the links of the reloaded document.
+	root.addLinks(m, 0, 1, nil)
+	if m.links == nil || len(m.links) != 0 || m.linksEnd != 1 {
+		t.Errorf("links = %v, linksEnd = %d, want no links and 1", m.links, m.linksEnd)
+	}
+}
+
+func TestCheckLinkURL(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		link     string
+		openFile bool
+		wantErr  bool
+	}{
+		{name: "https", link: "https://example.com/", wantErr: false},
+		{name: "http", link: "http://example.com/", wantErr: false},
+		{name: "file", link: "file:///tmp/a.txt", wantErr: true},
+		{name: "file allowed", link: "file:///tmp/a.txt", openFile: true, wantErr: false},
+		{name: "javascript", link: "javascript:alert(1)", wantErr: true},
+		{name: "no scheme", link: "example.com", wantErr: true},
+		{name: "option", link: "--help", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := checkLinkURL(tt.link, tt.openFile); (err != nil) != tt.wantErr {
+				t.Errorf("checkLinkURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRoot_runLinkOpener(t *testing.T) {
+	root := rootHelper(t)
+	root.Config.LinkOpener = "'unterminated"
+	if err := root.runLinkOpener("https://example.com"); err == nil {
+		t.Error("runLinkOpener() error = nil, want error")
+	}
+}
@@ -0,0 +1,135 @@
+package oviewer
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// logfmtPair is a key and value of logfmt.
+type logfmtPair struct {
+	key   string
+	value string
+}
+
+// logfmtColumns toggles between the logfmt column document and the original document.
+// The keys are entered at the prompt unless LogfmtKeys is set.
+func (root *Root) logfmtColumns(ctx context.Context) {
+	if root.toggleColumnDocument(ctx) {
+		return
+	}
+	if keys := root.Doc.LogfmtKeys; len(keys) > 0 {
+		root.setLogfmtKeys(ctx, strings.Join(keys, ","))
+		return
+	}
+	root.inputLogfmtKeys(ctx)
+}
+
+// setLogfmtKeys extracts the logfmt values into a new column document.
+// If no key is specified, the keys are used in the order in which they first appear.
+func (root *Root) setLogfmtKeys(ctx context.Context, input string) {
+	keys := parseColumnNames(input)
+	caption := "logfmt"
+	if len(keys) > 0 {
+		caption += ":" + strings.Join(keys, ",")
+	}
+	m := root.Doc
+	header := func(ctx context.Context) []string {
+		if len(keys) == 0 {
+			keys = m.logfmtKeys(ctx)
+		}
+		return keys
+	}
+	extract := func(line []byte) []string {
+		return logfmtValues(string(line), keys)
+	}
+	root.columnDocument(ctx, caption, header, extract)
+}
+
+// logfmtKeys returns the keys of the document in the order in which they first appear.
+func (m *Document) logfmtKeys(ctx context.Context) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	err := m.eachLine(ctx, m.firstLine(), func(_ int, line []byte) bool {
+		for _, pair := range parseLogfmt(string(line)) {
+			if seen[pair.key] {
+				continue
+			}
+			seen[pair.key] = true
+			keys = append(keys, pair.key)
+		}
+		return true
+	})
+	if err != nil {
+		log.Printf("failed to read logfmt keys: %v\n", err)
+	}
+	return keys
+}
+
+// logfmtValues returns the values of the keys in the logfmt line.
+// Missing keys are empty strings.
+// It returns nil if the line has no key=value pair.
+func logfmtValues(line string, keys []string) []string {
+	pairs := parseLogfmt(line)
+	if len(pairs) == 0 {
+		return nil
+	}
+	values := make([]string, len(keys))
+	for _, pair := range pairs {
+		for i, key := range keys {
+			if pair.key == key {
+				values[i] = pair.value
+			}
+		}
+	}
+	return values
+}
+
+// parseLogfmt parses the logfmt line into key and value pairs.
+// Quoted values are unquoted, and words without = are ignored.
+func parseLogfmt(line string) []logfmtPair {
+	var pairs []logfmtPair
+	for i := 0; i < len(line); {
+		if line[i] == ' ' || line[i] == '\t' {
+			i++
+			continue
+		}
+		start := i
+		for i < len(line) && line[i] != ' ' && line[i] != '\t' && line[i] != '=' {
+			i++
+		}
+		key := line[start:i]
+		if i >= len(line) || line[i] != '=' || key == "" {
+			// Skip the bare word.
+			for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+				i++
+			}
+			continue
+		}
+		i++
+		var value string
+		if i < len(line) && line[i] == '"' {
+			end := i + jsonStringEnd(line[i:])
+			value = unquoteLogfmt(line[i:end])
+			i = end
+		} else {
+			start := i
+			for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+				i++
+			}
+			value = line[start:i]
+		}
+		pairs = append(pairs, logfmtPair{key: key, value: value})
+	}
+	return pairs
+}
+
+// unquoteLogfmt returns the unquoted value.
+// If the value cannot be unquoted, the quotes are just removed.
+func unquoteLogfmt(quoted string) string {
+	if value, err := strconv.Unquote(quoted); err == nil {
+		return value
+	}
+	return strings.Trim(quoted, `"`)
+}
@@ -0,0 +1,175 @@
+package oviewer
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+func Test_parseLogfmt(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		line string
+		want []logfmtPair
+	}{
+		{
+			name: "simple",
+			line: "level=info msg=start",
+			want: []logfmtPair{{key: "level", value: "info"}, {key: "msg", value: "start"}},
+		},
+		{
+			name: "quoted",
+			line: `msg="hello world" level=info`,
+			want: []logfmtPair{{key: "msg", value: "hello world"}, {key: "level", value: "info"}},
+		},
+		{
+			name: "escaped quote",
+			line: `msg="say \"hi\"" n=1`,
+			want: []logfmtPair{{key: "msg", value: `say "hi"`}, {key: "n", value: "1"}},
+		},
+		{
+			name: "empty value",
+			line: "a= b=2",
+			want: []logfmtPair{{key: "a", value: ""}, {key: "b", value: "2"}},
+		},
+		{
+			name: "bare word",
+			line: "debug a=1 =x",
+			want: []logfmtPair{{key: "a", value: "1"}},
+		},
+		{
+			name: "unterminated quote",
+			line: `msg="abc`,
+			want: []logfmtPair{{key: "msg", value: "abc"}},
+		},
+		{
+			name: "plain text",
+			line: "plain text line",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := parseLogfmt(tt.line); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseLogfmt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_logfmtValues(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		line string
+		keys []string
+		want []string
+	}{
+		{
+			name: "order",
+			line: `msg="a b" level=info`,
+			keys: []string{"level", "msg"},
+			want: []string{"info", "a b"},
+		},
+		{
+			name: "missing",
+			line: "level=info",
+			keys: []string{"ts", "level"},
+			want: []string{"", "info"},
+		},
+		{
+			name: "not logfmt",
+			line: "plain text",
+			keys: []string{"level"},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := logfmtValues(tt.line, tt.keys); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("logfmtValues() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoot_setLogfmtKeys(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	tests := []struct {
+		name     string
+		input    string
+		wantLine map[int]string
+	}{
+		{
+			name:  "first seen order",
+			input: "",
+			wantLine: map[int]string{
+				0: "level\tts\tmsg\tport\tpath\tdur\terr",
+				2: "warn\t2024-01-01T00:00:01Z\tslow request\t\t/api\t1.2s\t",
+				3: "\t\t\t\t\t\t",
+			},
+		},
+		{
+			name:  "keys",
+			input: "ts,msg,err",
+			wantLine: map[int]string{
+				0: "ts\tmsg\terr",
+				1: "2024-01-01T00:00:00Z\tserver started\t",
+				4: "2024-01-01T00:00:02Z\tfailed: \"db\"\ttimeout",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := rootFileReadHelper(t, filepath.Join(testdata, "logfmt.log"))
+			root.Doc.WaitEOF()
+			root.setLogfmtKeys(context.Background(), tt.input)
+			if root.DocumentLen() != 2 {
+				t.Fatalf("setLogfmtKeys() documents = %v, want 2", root.DocumentLen())
+			}
+			doc := root.DocList[len(root.DocList)-1]
+			doc.WaitEOF()
+			for lN, want := range tt.wantLine {
+				line, err := doc.Line(lN)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(line) != want {
+					t.Errorf("setLogfmtKeys() line %d = %q, want %q", lN, line, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRoot_logfmtColumns_logfmtKeys(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "logfmt.log"))
+	root.Doc.WaitEOF()
+	root.Doc.LogfmtKeys = []string{"level", "msg"}
+	root.logfmtColumns(context.Background())
+	if root.DocumentLen() != 2 {
+		t.Fatalf("logfmtColumns() documents = %v, want 2", root.DocumentLen())
+	}
+	doc := root.DocList[len(root.DocList)-1]
+	doc.WaitEOF()
+	line, err := doc.Line(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "level\tmsg"; string(line) != want {
+		t.Errorf("logfmtColumns() header = %q, want %q", line, want)
+	}
+}
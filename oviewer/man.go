@@ -0,0 +1,149 @@
+package oviewer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ManCommand is the command to display the referenced man pages.
+var ManCommand = "man"
+
+// manSectionReg matches the headings of the man page such as NAME and SYNOPSIS.
+// The header and footer lines that end with the name of the page such as LS(1) are excluded.
+var manSectionReg = regexp.MustCompile(`^\S(?:.*[^)\s])?\s*$`)
+
+// manRefReg matches the references to the other man pages such as grep(1).
+// The name starting with "-" is not matched so that it is not taken as an option of the man command.
+var manRefReg = regexp.MustCompile(`(?:^|[\s,(])([\w.:+][\w.:+-]*)\((\d\w*|n)\)`)
+
+// optionSearcher is a Searcher that matches the definition lines of the option.
+// The definition line starts with the option, or with the other names of the option such as "-a, --all".
+type optionSearcher struct {
+	option string
+	regexp *regexp.Regexp
+}
+
+// newOptionSearcher returns the optionSearcher for the option.
+// The option without the leading hyphens is treated as the short option if it is one character,
+// otherwise as the long option.
+func newOptionSearcher(option string) Searcher {
+	option = strings.TrimSpace(option)
+	if option == "" {
+		return nil
+	}
+	if !strings.HasPrefix(option, "-") {
+		if len([]rune(option)) == 1 {
+			option = "-" + option
+		} else {
+			option = "--" + option
+		}
+	}
+	reg := regexp.MustCompile(`^\s*(?:-[^\s,]+(?:[ =]\S+)?,\s+)*(` + regexp.QuoteMeta(option) + `)(?:$|[\s,=\[])`)
+	return optionSearcher{option: option, regexp: reg}
+}
+
+// Match returns true if the line is the definition of the option.
+func (s optionSearcher) Match(target []byte) bool {
+	return s.regexp.Match(stripEscapeSequenceBytes(target))
+}
+
+// MatchString returns true if the line is the definition of the option.
+func (s optionSearcher) MatchString(target string) bool {
+	return s.regexp.MatchString(stripEscapeSequenceString(target))
+}
+
+// FindAll returns the position of the option in the definition line.
+func (s optionSearcher) FindAll(target string) [][]int {
+	var indexes [][]int
+	for _, idx := range s.regexp.FindAllStringSubmatchIndex(target, -1) {
+		indexes = append(indexes, []int{idx[2], idx[3]})
+	}
+	return indexes
+}
+
+// String returns the option.
+func (s optionSearcher) String() string {
+	return s.option
+}
+
+// jumpOption moves to the definition line of the option.
+func (root *Root) jumpOption(ctx context.Context, input string) {
+	searcher := newOptionSearcher(input)
+	if searcher == nil {
+		return
+	}
+	root.searcher = searcher
+	root.searchMove(ctx, true, root.Doc.BufStartNum(), searcher)
+}
+
+// manRefLinks returns the links of the references to the other man pages in the line.
+func manRefLinks(lN int, str string, pos widthPos, links []linkItem) []linkItem {
+	for _, idx := range manRefReg.FindAllStringSubmatchIndex(str, -1) {
+		start, end := pos.x(idx[2]), pos.x(idx[1])
+		if containsLink(links, start) {
+			continue
+		}
+		links = append(links, linkItem{lN: lN, url: str[idx[2]:idx[1]], man: true, start: start, end: end})
+	}
+	return links
+}
+
+// manPageName returns the name and the section of the reference such as grep(1).
+func manPageName(ref string) (string, string, bool) {
+	match := manRefReg.FindStringSubmatch(ref)
+	if match == nil {
+		return "", "", false
+	}
+	return match[1], match[2], true
+}
+
+// openManPage opens the referenced man page as a new document.
+// The man command runs in the background and the document is added when it finishes.
+func (root *Root) openManPage(ref string) error {
+	name, section, ok := manPageName(ref)
+	if !ok || strings.HasPrefix(name, "-") {
+		return fmt.Errorf("invalid man page reference '%s'", ref)
+	}
+	//nolint:gosec
+	c := exec.Command(ManCommand, section, "--", name)
+	c.Env = append(os.Environ(), "MAN_KEEP_FORMATTING=1")
+	if root.scr.vWidth > 0 {
+		c.Env = append(c.Env, "MANWIDTH="+strconv.Itoa(root.scr.vWidth))
+	}
+	settings := root.Doc.RunTimeSettings
+	root.setMessagef("opening man:%s", ref)
+	go func() {
+		m, err := manPageDocument(c, settings, ref)
+		if err != nil {
+			root.sendMessageLog(fmt.Sprintf("failed to run %s %s %s: %v", ManCommand, section, name, err))
+			return
+		}
+		root.sendAddDocument(m)
+	}()
+	return nil
+}
+
+// manPageDocument runs the man command and returns the document of its output.
+func manPageDocument(c *exec.Cmd, settings RunTimeSettings, ref string) (*Document, error) {
+	out, err := c.Output()
+	if err != nil {
+		return nil, err
+	}
+	m, err := NewDocument()
+	if err != nil {
+		return nil, err
+	}
+	m.RunTimeSettings = settings
+	m.ManMode = true
+	m.Caption = "man:" + ref
+	if err := m.ControlReader(bytes.NewReader(out), nil); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,118 @@
+package oviewer
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDocument_nextSection_man(t *testing.T) {
+	t.Parallel()
+	m := docFileReadHelper(t, filepath.Join(testdata, "man.txt"))
+	m.ManMode = true
+	ctx := context.Background()
+	want := []int{2, 5, 8, 23}
+	lN := 0
+	for _, w := range want {
+		got, err := m.nextSection(ctx, lN)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != w {
+			t.Errorf("nextSection(%d) = %d, want %d", lN, got, w)
+		}
+		lN = got
+	}
+	if _, err := m.nextSection(ctx, lN); err == nil {
+		t.Errorf("nextSection(%d) found the footer", lN)
+	}
+}
+
+func Test_optionSearcher(t *testing.T) {
+	t.Parallel()
+	m := docFileReadHelper(t, filepath.Join(testdata, "man.txt"))
+	tests := []struct {
+		option  string
+		want    int
+		wantErr bool
+	}{
+		{option: "--all", want: 12},
+		{option: "-a", want: 12},
+		{option: "a", want: 12},
+		{option: "all", want: 12},
+		{option: "--block-size", want: 15},
+		{option: "-I", want: 18},
+		{option: "ignore", want: 18},
+		{option: "-l", want: 21},
+		{option: "--sort", wantErr: true},
+		{option: "-c", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.option, func(t *testing.T) {
+			t.Parallel()
+			searcher := newOptionSearcher(tt.option)
+			got, err := m.SearchLine(context.Background(), searcher, 0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SearchLine() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("SearchLine() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_optionSearcher_FindAll(t *testing.T) {
+	t.Parallel()
+	searcher := newOptionSearcher("--ignore")
+	got := searcher.FindAll("       -I, --ignore=PATTERN")
+	want := [][]int{{11, 19}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll() = %v, want %v", got, want)
+	}
+	if newOptionSearcher(" ") != nil {
+		t.Error("newOptionSearcher() should return nil for the empty option")
+	}
+}
+
+func TestDocument_lineLinks_man(t *testing.T) {
+	t.Parallel()
+	m := docHelper(t, "")
+	lc := StrToContents("       dircolors(1), grep(1), stat(2)", 8)
+	if got := m.lineLinks(0, lc); got != nil {
+		t.Errorf("lineLinks() = %v, want nil without the man page mode", got)
+	}
+	m.ManMode = true
+	want := []linkItem{
+		{url: "dircolors(1)", man: true, start: 7, end: 19},
+		{url: "grep(1)", man: true, start: 21, end: 28},
+		{url: "stat(2)", man: true, start: 30, end: 37},
+	}
+	if got := m.lineLinks(0, lc); !reflect.DeepEqual(got, want) {
+		t.Errorf("lineLinks() = %v, want %v", got, want)
+	}
+}
+
+func Test_manPageName(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		ref         string
+		wantName    string
+		wantSection string
+		wantOK      bool
+	}{
+		{ref: "grep(1)", wantName: "grep", wantSection: "1", wantOK: true},
+		{ref: "SSL_read(3ssl)", wantName: "SSL_read", wantSection: "3ssl", wantOK: true},
+		{ref: "Data::Dumper(3pm)", wantName: "Data::Dumper", wantSection: "3pm", wantOK: true},
+		{ref: "grep", wantOK: false},
+		{ref: "-rf(1)", wantOK: false},
+		{ref: "--help(1)", wantOK: false},
+	}
+	for _, tt := range tests {
+		name, section, ok := manPageName(tt.ref)
+		if name != tt.wantName || section != tt.wantSection || ok != tt.wantOK {
+			t.Errorf("manPageName(%q) = %q, %q, %v, want %q, %q, %v", tt.ref, name, section, ok, tt.wantName, tt.wantSection, tt.wantOK)
+		}
+	}
+}
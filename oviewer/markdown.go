@@ -0,0 +1,344 @@
+package oviewer
+
+import (
+	"context"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// markdownInfo is the information of the markdown document
+// that is needed to render the lines and to find the headings.
+type markdownInfo struct {
+	// start is the line number of the first scanned line.
+	start int
+	// end is the line number after the last scanned line.
+	end int
+	// tabWidth is the tab width used to measure the code blocks.
+	tabWidth int
+	lines    []markdownLine
+	headings []markdownHeadingItem
+	// resume is the index of the line where the scan resumes when lines are added,
+	// because the last item such as a table may continue to the added lines.
+	resume int
+	// resumeState is the state of the scan at resume.
+	resumeState markdownState
+}
+
+// markdownState is the state of the scan carried over to the next line.
+type markdownState struct {
+	// fence is the fence of the fenced code block.
+	fence string
+	// block is the fenced code block that is not closed yet.
+	block     *markdownBlock
+	inList    bool
+	prevBlank bool
+}
+
+// markdownHeadingItem is a heading of the markdown document.
+type markdownHeadingItem struct {
+	lN    int
+	level int
+	title string
+}
+
+// line returns the information of the line.
+func (info *markdownInfo) line(lN int) markdownLine {
+	n := lN - info.start
+	if n < 0 || n >= len(info.lines) {
+		return markdownLine{}
+	}
+	return info.lines[n]
+}
+
+// scanMarkdown scans the lines and returns the information of the markdown document.
+func scanMarkdown(lines []string, tabWidth int) *markdownInfo {
+	info := &markdownInfo{
+		tabWidth:    tabWidth,
+		resumeState: markdownState{prevBlank: true},
+	}
+	info.scan(lines)
+	return info
+}
+
+// clone returns the copy of the information to be scanned further.
+// The lines and the headings are shared up to the resume point, which are not changed by the scan.
+func (info *markdownInfo) clone() *markdownInfo {
+	c := *info
+	c.lines = slices.Clip(info.lines[:info.resume])
+	n := sort.Search(len(info.headings), func(i int) bool {
+		return info.headings[i].lN >= info.start+info.resume
+	})
+	c.headings = slices.Clip(info.headings[:n])
+	return &c
+}
+
+// scan scans the lines from the resume point.
+// lines[0] is the line at the resume point.
+func (info *markdownInfo) scan(lines []string) {
+	base := info.resume
+	info.lines = append(info.lines[:base], make([]markdownLine, len(lines))...)
+	info.end = info.start + base + len(lines)
+	st := info.resumeState
+	for i := 0; i < len(lines); i++ {
+		// The fenced code block is scanned again from its start, because the width is of the whole block.
+		if st.block == nil {
+			info.resume, info.resumeState = base+i, st
+		}
+		str := stripEscapeSequenceString(strings.TrimRight(lines[i], "\r"))
+		if st.block != nil {
+			trimmed := strings.TrimSpace(str)
+			if strings.HasPrefix(trimmed, st.fence) && strings.Trim(trimmed, st.fence[:1]) == "" {
+				info.lines[base+i] = markdownLine{kind: markdownFence, block: st.block}
+				st.block = nil
+				continue
+			}
+			st.block.width = max(st.block.width, len(StrToContents(lines[i], info.tabWidth)))
+			info.lines[base+i] = markdownLine{kind: markdownCode, block: st.block}
+			continue
+		}
+		if match := markdownFenceReg.FindStringSubmatch(str); match != nil {
+			st.fence = match[1]
+			st.block = &markdownBlock{}
+			info.lines[base+i] = markdownLine{kind: markdownFence, block: st.block}
+			st.prevBlank = false
+			continue
+		}
+		if strings.TrimSpace(str) == "" {
+			info.lines[base+i] = markdownLine{list: st.inList}
+			st.prevBlank = true
+			continue
+		}
+		if match := markdownATXReg.FindStringSubmatch(str); match != nil {
+			info.addHeading(base+i, len(match[1]), match[2])
+			st.inList, st.prevBlank = false, false
+			continue
+		}
+		if n := info.scanTable(lines, base, i); n > 0 {
+			i += n - 1
+			st.inList, st.prevBlank = false, false
+			continue
+		}
+		isListItem := markdownListReg.MatchString(str) && !markdownRuleReg.MatchString(str)
+		if !isListItem && i+1 < len(lines) && !markdownQuoteReg.MatchString(str) {
+			next := stripEscapeSequenceString(strings.TrimRight(lines[i+1], "\r"))
+			if match := markdownSetextReg.FindStringSubmatch(next); match != nil {
+				level := 1
+				if match[1][0] == '-' {
+					level = 2
+				}
+				info.addHeading(base+i, level, strings.TrimSpace(str))
+				info.lines[base+i+1] = markdownLine{kind: markdownSetext, level: level}
+				i++
+				st.inList, st.prevBlank = false, false
+				continue
+			}
+		}
+		if markdownRuleReg.MatchString(str) {
+			info.lines[base+i] = markdownLine{kind: markdownRule}
+			st.inList, st.prevBlank = false, false
+			continue
+		}
+		switch {
+		case isListItem:
+			st.inList = true
+		case st.prevBlank && !isSpaceByte(str[0]):
+			st.inList = false
+		}
+		info.lines[base+i] = markdownLine{list: st.inList}
+		st.prevBlank = false
+	}
+}
+
+// addHeading adds the heading of the line.
+func (info *markdownInfo) addHeading(n int, level int, title string) {
+	info.lines[n] = markdownLine{kind: markdownHeading, level: level}
+	title = markdownInline(StrToContents(title, info.tabWidth)).String()
+	info.headings = append(info.headings, markdownHeadingItem{lN: info.start + n, level: level, title: title})
+}
+
+// scanTable scans the table that starts at lines[i], which is the line at base+i.
+// It returns the number of the lines of the table, or 0 if it is not a table.
+func (info *markdownInfo) scanTable(lines []string, base int, i int) int {
+	if i+1 >= len(lines) || !strings.Contains(lines[i], "|") {
+		return 0
+	}
+	delimiter := strings.TrimRight(lines[i+1], "\r")
+	if !strings.Contains(delimiter, "|") || !markdownTableDelimiterReg.MatchString(delimiter) {
+		return 0
+	}
+	table := newAlignConverter(false)
+	for _, cell := range markdownTableCells(delimiter) {
+		spec := strings.TrimSpace(delimiter[cell[0]:cell[1]])
+		attr := columnAttribute{}
+		switch {
+		case strings.HasSuffix(spec, ":") && !strings.HasPrefix(spec, ":"):
+			attr.specifiedAlign = RightAlign
+		case strings.HasPrefix(spec, ":"):
+			attr.specifiedAlign = LeftAlign
+		}
+		table.columnAttrs = append(table.columnAttrs, attr)
+	}
+	n := 0
+	for lN := i; lN < len(lines); lN++ {
+		line := strings.TrimRight(lines[lN], "\r")
+		kind := markdownTableRow
+		switch {
+		case lN == i:
+			kind = markdownTableHeader
+		case lN == i+1:
+			info.lines[base+lN] = markdownLine{kind: markdownTableDelimiter, table: table}
+			n++
+			continue
+		case strings.TrimSpace(line) == "" || !strings.Contains(line, "|"):
+			return n
+		}
+		for col, cell := range markdownTableCellContents(StrToContents(line, info.tabWidth), defaultMarkdownStyles) {
+			table.maxWidths, _ = updateMaxWidths(table.maxWidths, nil, col, len(cell), 0)
+		}
+		info.lines[base+lN] = markdownLine{kind: kind, table: table}
+		n++
+	}
+	return n
+}
+
+// markdownInfo returns the information of the whole markdown document.
+// When lines have been added, the scan resumes from the last item that may continue to them,
+// and the whole document is scanned again only when it has been reloaded.
+// It is used by the section moves and lists, which need all the headings.
+func (m *Document) markdownInfo() *markdownInfo {
+	return m.updateMarkdown(m.TabWidth)
+}
+
+// markdownLine returns the information of the line for the markdown converter.
+// The draw does not wait for the scan. If the document has not been scanned to the end,
+// the scan runs in the background and the lines are drawn with the information scanned so far.
+func (m *Document) markdownLine(lN int) markdownLine {
+	start, end := m.BufStartNum(), m.BufEndNum()
+	m.markdownMu.Lock()
+	info := m.markdown
+	m.markdownMu.Unlock()
+	if info == nil || info.end != end || info.tabWidth != m.TabWidth {
+		if m.markdownRunning.CompareAndSwap(false, true) {
+			tabWidth := m.TabWidth
+			go func() {
+				defer m.markdownRunning.Store(false)
+				m.updateMarkdown(tabWidth)
+			}()
+		}
+	}
+	// The information before reloading is not used.
+	if info == nil || info.start != start {
+		return markdownLine{}
+	}
+	return info.line(lN)
+}
+
+// updateMarkdown scans the lines of the markdown document that have not been scanned and returns the information.
+// The information is stored for each chunk of the lines, so that the lines are drawn with it during the scan.
+// The lines are read without holding the lock so that the draw is not blocked by the scan.
+func (m *Document) updateMarkdown(tabWidth int) *markdownInfo {
+	start, end := m.BufStartNum(), m.BufEndNum()
+	m.markdownMu.Lock()
+	info := m.markdown
+	m.markdownMu.Unlock()
+	if info != nil && info.start == start && info.end == end && info.tabWidth == tabWidth {
+		return info
+	}
+
+	var next *markdownInfo
+	if info != nil && info.start == start && info.end < end && info.tabWidth == tabWidth {
+		next = info.clone()
+	} else {
+		next = &markdownInfo{start: start, end: start, tabWidth: tabWidth, resumeState: markdownState{prevBlank: true}}
+	}
+	// lines are the lines from the resume point.
+	var lines []string
+	readEnd := start + next.resume
+	for {
+		// The lines scanned before are scanned again at once.
+		chunkEnd := min(max(readEnd+ChunkSize, next.end), end)
+		err := m.eachLine(context.Background(), readEnd, func(lN int, line []byte) bool {
+			if lN >= chunkEnd {
+				return false
+			}
+			lines = append(lines, string(line))
+			return true
+		})
+		if err != nil {
+			log.Printf("failed to scan markdown: %v\n", err)
+		}
+		readEnd = chunkEnd
+		resume := next.resume
+		next.scan(lines)
+		stored := m.storeMarkdown(info, next)
+		if stored != next || readEnd >= end || err != nil {
+			return stored
+		}
+		info = next
+		next = next.clone()
+		lines = lines[next.resume-resume:]
+	}
+}
+
+// storeMarkdown stores the scanned information and returns the stored information.
+// The information scanned further by another goroutine is kept.
+func (m *Document) storeMarkdown(prev *markdownInfo, info *markdownInfo) *markdownInfo {
+	m.markdownMu.Lock()
+	defer m.markdownMu.Unlock()
+	if cur := m.markdown; cur != prev && cur != nil && cur.start == info.start && cur.tabWidth == info.tabWidth && cur.end >= info.end {
+		return cur
+	}
+	m.markdown = info
+	// The lines drawn before may be changed by the scanned lines.
+	m.cache.Purge()
+	atomic.StoreInt32(&m.store.changed, 1)
+	return info
+}
+
+// markdownSectionEnabled returns true if the headings of the markdown converter are the sections.
+func (m *Document) markdownSectionEnabled() bool {
+	return m.sectionKind() == sectionMarkdown
+}
+
+// isMarkdownHeading returns true if the line is a heading of the markdown.
+func (m *Document) isMarkdownHeading(lN int) bool {
+	return m.markdownLine(lN).kind == markdownHeading
+}
+
+// nextMarkdownHeading returns the line number of the first heading at or after lN.
+func (m *Document) nextMarkdownHeading(lN int) (int, error) {
+	headings := m.markdownInfo().headings
+	n := sort.Search(len(headings), func(i int) bool {
+		return headings[i].lN >= lN
+	})
+	if n >= len(headings) {
+		return 0, ErrNotFound
+	}
+	return headings[n].lN, nil
+}
+
+// prevMarkdownHeading returns the line number of the last heading at or before lN.
+func (m *Document) prevMarkdownHeading(lN int) (int, error) {
+	headings := m.markdownInfo().headings
+	n := sort.Search(len(headings), func(i int) bool {
+		return headings[i].lN > lN
+	})
+	if n == 0 {
+		return 0, ErrNotFound
+	}
+	return headings[n-1].lN, nil
+}
+
+// markdownSections returns the list of the headings.
+// The level of the sections is the level of the headings.
+func (m *Document) markdownSections(_ context.Context, offset int) MatchedLineList {
+	headings := m.markdownInfo().headings
+	sections := make(MatchedLineList, 0, len(headings))
+	for _, h := range headings {
+		sections = append(sections, MatchedLine{lineNum: h.lN + offset, line: []byte(h.title), level: h.level - 1})
+	}
+	return sections
+}
@@ -0,0 +1,161 @@
+package oviewer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_scanMarkdown(t *testing.T) {
+	t.Parallel()
+	m := docFileReadHelper(t, filepath.Join(testdata, "markdown.md"))
+	info := m.markdownInfo()
+	wantHeadings := []markdownHeadingItem{
+		{lN: 0, level: 1, title: "Title"},
+		{lN: 4, level: 2, title: "Install"},
+		{lN: 16, level: 2, title: "Usage"},
+		{lN: 22, level: 3, title: "Details"},
+	}
+	if !reflect.DeepEqual(info.headings, wantHeadings) {
+		t.Errorf("headings = %v, want %v", info.headings, wantHeadings)
+	}
+	tests := []struct {
+		lN   int
+		want markdownKind
+	}{
+		{lN: 2, want: markdownText},
+		{lN: 6, want: markdownFence},
+		{lN: 7, want: markdownCode},
+		{lN: 9, want: markdownFence},
+		{lN: 11, want: markdownTableHeader},
+		{lN: 12, want: markdownTableDelimiter},
+		{lN: 14, want: markdownTableRow},
+		{lN: 17, want: markdownSetext},
+	}
+	for _, tt := range tests {
+		if got := info.line(tt.lN).kind; got != tt.want {
+			t.Errorf("line(%d).kind = %v, want %v", tt.lN, got, tt.want)
+		}
+	}
+	if got := info.line(14).table.maxWidths; !reflect.DeepEqual(got, []int{9, 5}) {
+		t.Errorf("table maxWidths = %v, want %v", got, []int{9, 5})
+	}
+	if !info.line(20).list {
+		t.Errorf("line(20) is not in the list")
+	}
+}
+
+func Test_markdownInfo_scanResume(t *testing.T) {
+	t.Parallel()
+	buf, err := os.ReadFile(filepath.Join(testdata, "markdown.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(buf), "\n"), "\n")
+	want := scanMarkdown(lines, 8)
+	for n := 1; n < len(lines); n++ {
+		info := scanMarkdown(lines[:n], 8)
+		got := info.clone()
+		got.scan(lines[got.resume:])
+		if !reflect.DeepEqual(got.headings, want.headings) {
+			t.Errorf("resume after %d lines: headings = %v, want %v", n, got.headings, want.headings)
+		}
+		if !reflect.DeepEqual(got.lines, want.lines) {
+			t.Errorf("resume after %d lines: lines differ", n)
+		}
+	}
+}
+
+func TestDocument_nextSection_markdown(t *testing.T) {
+	t.Parallel()
+	m := docFileReadHelper(t, filepath.Join(testdata, "markdown.md"))
+	m.Converter = convMarkdown
+	ctx := context.Background()
+	want := []int{4, 16, 22}
+	lN := 0
+	for _, w := range want {
+		got, err := m.nextSection(ctx, lN)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != w {
+			t.Errorf("nextSection(%d) = %d, want %d", lN, got, w)
+		}
+		lN = got
+	}
+	if _, err := m.nextSection(ctx, lN); err == nil {
+		t.Errorf("nextSection(%d) found a heading", lN)
+	}
+	got, err := m.prevSection(ctx, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 4 {
+		t.Errorf("prevSection(16) = %d, want 4", got)
+	}
+}
+
+func TestDocument_markdownSections(t *testing.T) {
+	t.Parallel()
+	m := docFileReadHelper(t, filepath.Join(testdata, "markdown.md"))
+	m.Converter = convMarkdown
+	got := m.markdownSections(context.Background(), 0)
+	want := []string{"Title", "Install", "Usage", "Details"}
+	wantLevels := []int{0, 1, 1, 2}
+	if len(got) != len(want) {
+		t.Fatalf("markdownSections() = %d sections, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if string(got[i].line) != w || got[i].level != wantLevels[i] {
+			t.Errorf("markdownSections()[%d] = %q, %d, want %q, %d", i, got[i].line, got[i].level, w, wantLevels[i])
+		}
+	}
+}
+
+func TestDocument_markdownLine(t *testing.T) {
+	t.Parallel()
+	m := docFileReadHelper(t, filepath.Join(testdata, "markdown.md"))
+	m.WaitEOF()
+	m.Converter = convMarkdown
+	// The line is drawn without waiting for the scan in the background.
+	m.markdownLine(0)
+	timeout := time.After(5 * time.Second)
+	for m.markdownLine(0).kind != markdownHeading {
+		select {
+		case <-timeout:
+			t.Fatal("timeout waiting for the markdown scan")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if got := m.markdownLine(7).kind; got != markdownCode {
+		t.Errorf("markdownLine(7).kind = %v, want %v", got, markdownCode)
+	}
+}
+
+func TestDocument_markdownInfo_chunks(t *testing.T) {
+	t.Parallel()
+	lines := make([]string, ChunkSize*2)
+	for i := range lines {
+		lines[i] = "text"
+	}
+	lines[0] = "# Title"
+	// The fenced code block continues to the next chunk.
+	lines[ChunkSize-2] = "```"
+	lines[ChunkSize+2] = "```"
+	lines[ChunkSize+10] = "## Next"
+	m := docHelper(t, strings.Join(lines, "\n")+"\n")
+	info := m.markdownInfo()
+	want := scanMarkdown(lines, m.TabWidth)
+	if !reflect.DeepEqual(info.headings, want.headings) {
+		t.Errorf("headings = %v, want %v", info.headings, want.headings)
+	}
+	for _, lN := range []int{ChunkSize - 2, ChunkSize, ChunkSize + 2, ChunkSize + 10} {
+		if got := info.line(lN).kind; got != want.line(lN).kind {
+			t.Errorf("line(%d).kind = %v, want %v", lN, got, want.line(lN).kind)
+		}
+	}
+}
@@ -194,6 +194,12 @@ func (root *Root) handlePrimaryButtonClick(ctx context.Context, ev *tcell.EventM
 		return root.extendSelectionWithAltClick(ev)
 	}
 
+	// Shift+click opens the link under the mouse.
+	if mod&tcell.ModShift != 0 && root.openLinkAt(x, y) {
+		root.resetClickState()
+		return true
+	}
+
 	clickType := root.checkClickType(x, y, now)
 
 	switch clickType {
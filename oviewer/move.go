@@ -236,7 +236,9 @@ func (root *Root) moveColumnLeft(n int) {
 	if err := root.Doc.moveColumnLeft(n, root.scr, !root.Config.DisableColumnCycle); err != nil {
 		root.setMessagef("Cannot move column: %s", err)
 		root.debugMessage(err.Error())
+		return
 	}
+	root.columnNameMessage()
 }
 
 // moveColumnRight moves the cursor to the right by n amount.
@@ -244,5 +246,7 @@ func (root *Root) moveColumnRight(n int) {
 	if err := root.Doc.moveColumnRight(n, root.scr, !root.Config.DisableColumnCycle); err != nil {
 		root.setMessagef("Cannot move column: %s", err)
 		root.debugMessage(err.Error())
+		return
 	}
+	root.columnNameMessage()
 }
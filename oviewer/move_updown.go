@@ -259,19 +259,25 @@ func leftX(width int, lc contents) []int {
 
 // nextSection returns the line number of the next section.
 func (m *Document) nextSection(ctx context.Context, lN int) (int, error) {
-	searcher := NewSearcher(m.SectionDelimiter, m.SectionDelimiterReg, true, true)
+	if m.markdownSectionEnabled() {
+		return m.nextMarkdownHeading(lN + 1)
+	}
+	searcher := m.sectionSearcher()
 	return m.SearchLine(ctx, searcher, lN+1)
 }
 
 // prevSection returns the line number of the previous section.
 func (m *Document) prevSection(ctx context.Context, lN int) (int, error) {
-	searcher := NewSearcher(m.SectionDelimiter, m.SectionDelimiterReg, true, true)
+	if m.markdownSectionEnabled() {
+		return m.prevMarkdownHeading(lN - 1)
+	}
+	searcher := m.sectionSearcher()
 	return m.BackSearchLine(ctx, searcher, lN-1)
 }
 
 // moveNextSection moves to the next section.
 func (m *Document) moveNextSection(ctx context.Context) error {
-	if m.SectionDelimiter == "" {
+	if !m.sectionEnabled() {
 		return ErrNoDelimiter
 	}
 
@@ -290,7 +296,7 @@ func (m *Document) movePrevSection(ctx context.Context) error {
 
 // movePrevSectionLN moves to the previous section by line number.
 func (m *Document) movePrevSectionLN(ctx context.Context, start int) error {
-	if m.SectionDelimiter == "" {
+	if !m.sectionEnabled() {
 		return ErrNoDelimiter
 	}
 
@@ -54,6 +54,8 @@ type Root struct {
 	input *Input
 	// cancelFunc saves the cancel function, which is a time-consuming process.
 	cancelFunc context.CancelFunc
+	// searchCancelFunc saves the cancel function of the search running in the background.
+	searchCancelFunc context.CancelFunc
 
 	// searcher is the search structure.
 	searcher Searcher
@@ -121,6 +123,10 @@ type Root struct {
 	sidebarWidth int
 	// sidebarScrolls holds scroll positions for each sidebarMode.
 	sidebarScrolls map[SidebarMode]sidebarScroll
+	// columnStats is the statistics of the column displayed in the sidebar.
+	columnStats *columnStats
+	// columnStatsRunning guards concurrent column statistics computation.
+	columnStatsRunning atomic.Bool
 }
 
 const (
@@ -150,6 +156,8 @@ type SCR struct {
 
 	// rulerHeight is the height of the ruler.
 	rulerHeight int
+	// columnNamesHeight is the height of the synthetic header of the column names.
+	columnNamesHeight int
 	// HeaderLN is the number of header lines.
 	headerLN int
 	// headerEnd is the end of the header.
@@ -229,6 +237,10 @@ var (
 	OverLineStyle tcell.Style
 	// SkipExtract is a flag to skip extracting compressed files.
 	SkipExtract bool
+	// Preprocessors are the commands that convert the input files before they are read.
+	Preprocessors []Preprocessor
+	// LessOpen is a flag to preprocess the input files with the LESSOPEN environment variable.
+	LessOpen bool
 )
 
 // ov output destination.
@@ -321,6 +333,16 @@ var (
 	ErrInvalidRGBColor = errors.New("invalid RGB color")
 	// ErrInvalidKey indicates that the key format is invalid.
 	ErrInvalidKey = errors.New("invalid key format")
+	// ErrInvalidCSVDelimiter indicates that the delimiter cannot be used for CSV.
+	ErrInvalidCSVDelimiter = errors.New("invalid CSV delimiter")
+	// ErrInvalidSortOrder indicates that the sort order is invalid.
+	ErrInvalidSortOrder = errors.New("invalid sort order")
+	// ErrInvalidExportFormat indicates that the export format is invalid.
+	ErrInvalidExportFormat = errors.New("invalid export format")
+	// ErrInvalidFilterExpr indicates that the filter expression is invalid.
+	ErrInvalidFilterExpr = errors.New("invalid filter expression")
+	// ErrInvalidTransform indicates that the column transform is invalid.
+	ErrInvalidTransform = errors.New("invalid column transform")
 )
 
 // This is a function of tcell.NewScreen but can be replaced with mock.
@@ -574,6 +596,13 @@ func (root *Root) watchEvent(event fsnotify.Event) {
 		if m.filepath == event.Name {
 			switch event.Op {
 			case fsnotify.Write:
+				// The preprocessor is run again to follow the changes of the file.
+				if m.preprocessed.Load() {
+					if m.followModeEnabled() || m.followAllEnabled() {
+						root.sendRequest(m, requestReload)
+					}
+					continue
+				}
 				root.sendRequest(m, requestFollow)
 			case fsnotify.Remove, fsnotify.Create:
 				if m.FollowName {
@@ -668,6 +697,7 @@ func (root *Root) Run() error {
 	quitChan := make(chan struct{})
 
 	root.monitorEOF()
+	root.monitorFormat()
 	go func() {
 		// Undo screen when goroutine panic.
 		defer func() {
@@ -729,6 +759,10 @@ func (root *Root) prepareRun(ctx context.Context) error {
 		root.Config.QuitSmall = false
 	}
 	root.ViewSync(ctx)
+	// The vt converter is applied after the screen size is determined.
+	if root.Doc.Converter == convVT {
+		root.vtDocument(ctx)
+	}
 	return nil
 }
 
@@ -790,6 +824,18 @@ func (root *Root) prepareAllDocuments() {
 func (root *Root) Close() {
 	root.screenState.Store(ScreenStateTerminated)
 	root.Screen.Fini()
+	root.closePreprocessed()
+}
+
+// closePreprocessed closes the documents of the preprocessor so that LESSCLOSE is run.
+func (root *Root) closePreprocessed() {
+	root.mu.RLock()
+	defer root.mu.RUnlock()
+	for _, doc := range root.DocList {
+		if doc.preprocessed.Load() && !doc.checkClose() {
+			doc.requestClose()
+		}
+	}
 }
 
 // setMessagef displays a formatted message in status.
@@ -123,8 +123,14 @@ func (root *Root) prepareDraw(ctx context.Context) {
 	// Header.
 	root.scr.headerLN = root.Doc.SkipLines
 	root.scr.headerEnd = root.Doc.firstLine()
+	// The column names are displayed above the header.
+	root.scr.columnNamesHeight = 0
+	if root.Doc.showColumnNames() {
+		root.scr.columnNamesHeight = 1
+	}
+	root.Doc.bodyStartY = root.scr.rulerHeight + root.scr.columnNamesHeight
 	// Set the header height.
-	root.Doc.headerHeight = min(root.scr.vHeight, root.Doc.getHeight(root.scr.headerLN, root.scr.headerEnd)+root.scr.rulerHeight)
+	root.Doc.headerHeight = min(root.scr.vHeight, root.Doc.getHeight(root.scr.headerLN, root.scr.headerEnd)+root.Doc.bodyStartY)
 
 	// Section header.
 	root.scr.sectionHeaderLN = -1
@@ -190,20 +196,24 @@ func (root *Root) setAlignConverter() {
 	if !m.ColumnWidth {
 		root.Doc.alignConv.delimiter = m.ColumnDelimiter
 		root.Doc.alignConv.delimiterReg = m.ColumnDelimiterReg
+		root.Doc.alignConv.columnReg = m.ColumnRegexpReg
 	}
 
 	maxWidths := make([]int, 0, len(m.alignConv.maxWidths))
 	addRight := make([]int, 0, len(m.alignConv.maxWidths))
-	for ln := root.scr.headerLN; ln < root.scr.headerEnd; ln++ {
+	lines := root.alignLineNumbers()
+	for _, ln := range lines {
 		maxWidths, addRight = m.maxColumnWidths(maxWidths, addRight, ln)
 	}
-	for ln := root.scr.sectionHeaderLN; ln < root.scr.sectionHeaderEnd; ln++ {
-		maxWidths, addRight = m.maxColumnWidths(maxWidths, addRight, ln)
+	if m.showColumnNames() {
+		maxWidths, _ = m.lineColumnWidths(maxWidths, nil, m.columnNamesLine())
 	}
-	startLN := m.topLN + m.firstLine()
-	for ln := startLN; ln < startLN+root.scr.vHeight; ln++ {
-		maxWidths, addRight = m.maxColumnWidths(maxWidths, addRight, ln)
+
+	if m.AlignStable {
+		root.updateStableWidths(m)
+		maxWidths = m.mergeStableWidths(maxWidths)
 	}
+	maxWidths = m.transformedWidths(maxWidths, lines)
 
 	// Apply shrink width to shrunk columns before comparing.
 	for i, attr := range m.alignConv.columnAttrs {
@@ -212,6 +222,7 @@ func (root *Root) setAlignConverter() {
 		}
 	}
 	if slices.Equal(m.alignConv.maxWidths, maxWidths) {
+		m.applyColumnSettings()
 		return
 	}
 	m.alignConv.orgWidths = m.columnWidths
@@ -225,9 +236,27 @@ func (root *Root) setAlignConverter() {
 			m.alignConv.columnAttrs[i].rightAlign = addRight[i] > 1
 		}
 	}
+	m.applyColumnSettings()
 	m.ClearCache()
 }
 
+// alignLineNumbers returns the line numbers of the header, the section header
+// and the body on the screen, which determine the column widths.
+func (root *Root) alignLineNumbers() []int {
+	var lines []int
+	for ln := root.scr.headerLN; ln < root.scr.headerEnd; ln++ {
+		lines = append(lines, ln)
+	}
+	for ln := max(root.scr.sectionHeaderLN, 0); ln < root.scr.sectionHeaderEnd; ln++ {
+		lines = append(lines, ln)
+	}
+	startLN := root.Doc.topLN + root.Doc.firstLine()
+	for ln := startLN; ln < startLN+root.scr.vHeight; ln++ {
+		lines = append(lines, ln)
+	}
+	return lines
+}
+
 // maxColumnWidths returns the maximum width of the column.
 func (m *Document) maxColumnWidths(maxWidths []int, rightCount []int, lN int) ([]int, []int) {
 	if lN < 0 {
@@ -237,22 +266,70 @@ func (m *Document) maxColumnWidths(maxWidths []int, rightCount []int, lN int) ([
 	if err != nil {
 		return maxWidths, rightCount
 	}
-	lc := StrToContents(str, m.TabWidth)
-	if m.ColumnWidth {
-		return maxWidthsWidth(lc, maxWidths, rightCount, m.columnWidths)
+	return m.lineColumnWidths(maxWidths, rightCount, str)
+}
+
+// lineColumnWidths returns the maximum width of the column updated with the line.
+func (m *Document) lineColumnWidths(maxWidths []int, rightCount []int, str string) ([]int, []int) {
+	return m.columnWidthSettings().lineColumnWidths(maxWidths, rightCount, str)
+}
+
+// columnWidthSettings is the column settings of the document that decide the column widths.
+// It is taken on the event loop so that the widths can be computed in the background.
+type columnWidthSettings struct {
+	tabWidth     int
+	columnWidth  bool
+	columnWidths []int
+	columnReg    *regexp.Regexp
+	delimiter    string
+	delimiterReg *regexp.Regexp
+}
+
+// columnWidthSettings returns the current column settings of the document.
+func (m *Document) columnWidthSettings() columnWidthSettings {
+	return columnWidthSettings{
+		tabWidth:     m.TabWidth,
+		columnWidth:  m.ColumnWidth,
+		columnWidths: m.columnWidths,
+		columnReg:    m.ColumnRegexpReg,
+		delimiter:    m.ColumnDelimiter,
+		delimiterReg: m.ColumnDelimiterReg,
 	}
-	return maxWidthsDelm(lc, maxWidths, rightCount, m.ColumnDelimiter, m.ColumnDelimiterReg)
+}
+
+// lineColumnWidths returns the maximum width of the column updated with the line.
+func (s columnWidthSettings) lineColumnWidths(maxWidths []int, rightCount []int, str string) ([]int, []int) {
+	lc := StrToContents(str, s.tabWidth)
+	if s.columnWidth {
+		return maxWidthsWidth(lc, maxWidths, rightCount, s.columnWidths)
+	}
+	if s.columnReg != nil {
+		return maxWidthsRegexp(lc, maxWidths, rightCount, s.columnReg)
+	}
+	return maxWidthsDelm(lc, maxWidths, rightCount, s.delimiter, s.delimiterReg)
 }
 
 // maxWidthsDelm returns the maximum width of the column.
 func maxWidthsDelm(lc contents, maxWidths []int, rightCount []int, delimiter string, delimiterReg *regexp.Regexp) ([]int, []int) {
 	str, pos := ContentsToStr(lc)
 	indexes := allIndex(str, delimiter, delimiterReg)
+	return maxWidthsIndex(lc, pos, 0, indexes, maxWidths, rightCount)
+}
+
+// maxWidthsRegexp returns the maximum width of the column split by the named groups.
+func maxWidthsRegexp(lc contents, maxWidths []int, rightCount []int, columnReg *regexp.Regexp) ([]int, []int) {
+	str, pos := ContentsToStr(lc)
+	first, indexes := regexpColumnIndex(str, columnReg)
+	return maxWidthsIndex(lc, pos, first, indexes, maxWidths, rightCount)
+}
+
+// maxWidthsIndex returns the maximum width of the column from first split at the delimiter indexes.
+func maxWidthsIndex(lc contents, pos widthPos, first int, indexes [][]int, maxWidths []int, rightCount []int) ([]int, []int) {
 	if len(indexes) == 0 {
 		return maxWidths, rightCount
 	}
 
-	start := pos.x(0)
+	start := pos.x(first)
 	for i := range indexes {
 		end := pos.x(indexes[i][0])
 		delmEnd := pos.x(indexes[i][1])
@@ -376,7 +453,7 @@ func (root *Root) sectionHeader(ctx context.Context, topLN int, timeout time.Dur
 
 // searchSectionHeader searches for the section header.
 func (m *Document) searchSectionHeader(ctx context.Context, lN int, timeout time.Duration) (int, error) {
-	if !m.SectionHeader || m.SectionDelimiter == "" {
+	if !m.SectionHeader || !m.sectionEnabled() {
 		return 0, ErrNoDelimiter
 	}
 
@@ -410,6 +487,7 @@ func (m *Document) getHeight(startLN int, endLN int) int {
 // prepareLines returns the contents of the screen.
 func (root *Root) prepareLines(lines map[int]LineC) map[int]LineC {
 	clear(lines)
+	root.Doc.diffCache = nil
 	// Header.
 	lines = root.setLines(lines, root.scr.headerLN, root.scr.headerEnd)
 	// Section header.
@@ -442,6 +520,12 @@ func (root *Root) lineContent(lN int) LineC {
 		lineC = m.columnRanges(lineC)
 	}
 	RangeStyle(lineC.lc, 0, len(lineC.lc), m.Style.Body)
+	if m.DiffMode {
+		root.diffStyle(lN, lineC)
+	}
+	if m.Syntax != "" {
+		root.syntaxHighlight(lineC)
+	}
 	root.styleContent(lineC)
 	return lineC
 }
@@ -449,10 +533,10 @@ func (root *Root) lineContent(lN int) LineC {
 // sectionNum sets the section number.
 func (root *Root) sectionNum(lines map[int]LineC) map[int]LineC {
 	m := root.Doc
-	if m.SectionDelimiter == "" {
+	if !m.sectionEnabled() {
 		return lines
 	}
-	if m.SectionDelimiterReg == nil {
+	if m.SectionDelimiter != "" && m.SectionDelimiterReg == nil {
 		log.Printf("Regular expression is not set: %s\n", m.SectionDelimiter)
 		return lines
 	}
@@ -477,7 +561,7 @@ func (root *Root) sectionNum(lines map[int]LineC) map[int]LineC {
 				// section starts off screen.
 				sp = m.getLineC(lN - m.SectionStartPosition)
 			}
-			if m.SectionDelimiterReg.MatchString(sp.str) {
+			if m.isSectionLine(lN-m.SectionStartPosition, sp) {
 				num = 1
 				section++
 			}
@@ -572,13 +656,13 @@ func (m *Document) columnRanges(lineC LineC) LineC {
 
 // columnDelimiterRange returns the ranges of the columns.
 func (m *Document) columnDelimiterRange(lineC LineC) []columnRange {
-	indexes := allIndex(lineC.str, m.ColumnDelimiter, m.ColumnDelimiterReg)
+	first, indexes := columnDelimiterIndex(lineC.str, m.ColumnDelimiter, m.ColumnDelimiterReg, m.ColumnRegexpReg)
 	if len(indexes) == 0 {
 		return nil
 	}
 
 	var columnRanges []columnRange
-	start := lineC.pos.x(0)
+	start := lineC.pos.x(first)
 	for columnNum := range indexes {
 		end := lineC.pos.x(indexes[columnNum][0])
 		delmEnd := lineC.pos.x(indexes[columnNum][1])
@@ -600,11 +684,16 @@ func (m *Document) columnWidthRanges(lineC LineC) []columnRange {
 		return nil
 	}
 
+	num := len(indexes) + 1
+	widths := m.alignConv.displayWidths()
+	if m.Converter == convAlign && m.alignConv.isReordered() {
+		num = len(widths)
+	}
 	var columnRanges []columnRange
 	start, end := 0, 0
-	for c := range len(indexes) + 1 {
+	for c := range num {
 		if m.Converter == convAlign {
-			end = alignColumnEnd(lineC.lc, m.alignConv.maxWidths, c, start)
+			end = alignColumnEnd(lineC.lc, widths, c, start)
 		} else {
 			end = findColumnEnd(lineC.lc, indexes, c, start)
 		}
@@ -622,6 +711,11 @@ func (root *Root) applyColumnStyles(lineC LineC) {
 	m := root.Doc
 	numC := len(m.Style.ColumnRainbow)
 
+	// Flag the line that does not match the column regular expression.
+	if m.ColumnRegexpReg != nil && !m.ColumnWidth && len(lineC.columnRanges) == 0 {
+		RangeStyle(lineC.lc, 0, len(lineC.lc), m.Style.ColumnUnmatched)
+		return
+	}
 	for c, colRange := range lineC.columnRanges {
 		if m.ColumnRainbow && numC > 0 {
 			RangeStyle(lineC.lc, colRange.start, colRange.end, m.Style.ColumnRainbow[c%numC])
@@ -0,0 +1,207 @@
+package oviewer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Preprocessor is a command that converts the input file before it is read.
+// The standard output of the command replaces the content of the file.
+type Preprocessor struct {
+	// Command is the command line run by the shell.
+	// "%s" is replaced with the file name.
+	// A leading "|" reads the output of the command, and falls back to the file itself when it outputs nothing.
+	// A leading "||" falls back to the file itself only when the command outputs nothing and fails.
+	Command string
+	// Extensions are the extensions of the files to preprocess, such as ".pdf".
+	Extensions []string
+	// MIMETypes are the MIME types of the files to preprocess, such as "application/pdf" or "image/*".
+	MIMETypes []string
+}
+
+// PreprocessorShell is the shell that runs the command of the preprocessor.
+var PreprocessorShell = "sh"
+
+// match returns true if the file matches the extensions and MIME types of the preprocessor.
+// A preprocessor without extensions and MIME types matches all files.
+func (p Preprocessor) match(fileName string, mimeType string) bool {
+	if len(p.Extensions) == 0 && len(p.MIMETypes) == 0 {
+		return true
+	}
+	ext := strings.ToLower(filepath.Ext(fileName))
+	for _, e := range p.Extensions {
+		if strings.ToLower("."+strings.TrimPrefix(e, ".")) == ext {
+			return true
+		}
+	}
+	if mimeType == "" {
+		return false
+	}
+	for _, t := range p.MIMETypes {
+		if prefix, ok := strings.CutSuffix(t, "/*"); ok {
+			if strings.HasPrefix(mimeType, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if t == mimeType {
+			return true
+		}
+	}
+	return false
+}
+
+// fileMIMEType returns the MIME type of the file from its first bytes.
+// It returns an empty string if the file cannot be read without moving the offset.
+func fileMIMEType(f *os.File) string {
+	buf := make([]byte, 512)
+	n, err := f.ReadAt(buf, 0)
+	if err != nil && err != io.EOF {
+		return ""
+	}
+	mimeType, _, _ := strings.Cut(http.DetectContentType(buf[:n]), ";")
+	return mimeType
+}
+
+// preprocessorCommand returns the command of the preprocessor for the file.
+// The preprocessors in the config take precedence over LESSOPEN.
+func preprocessorCommand(fileName string, f *os.File) string {
+	mimeType := ""
+	for _, p := range Preprocessors {
+		if len(p.MIMETypes) > 0 && mimeType == "" {
+			mimeType = fileMIMEType(f)
+		}
+		if p.match(fileName, mimeType) {
+			return p.Command
+		}
+	}
+	if LessOpen {
+		return os.Getenv("LESSOPEN")
+	}
+	return ""
+}
+
+// preprocess runs the preprocessor for the file and returns the file and the reader to read instead.
+// It returns nil if the file is not preprocessed.
+func (m *Document) preprocess(f *os.File) (*os.File, io.Reader) {
+	if f == os.Stdin || m.FileName == "" {
+		return nil, nil
+	}
+	command := preprocessorCommand(m.FileName, f)
+	if command == "" {
+		return nil, nil
+	}
+	var pf *os.File
+	var r io.Reader
+	var err error
+	if pipe, ok := strings.CutPrefix(command, "|"); ok {
+		pf, r, err = runPipePreprocessor(pipe, m.FileName)
+	} else {
+		pf, err = runFilePreprocessor(command, m.FileName)
+		r = pf
+		if pf != nil {
+			m.replacement = pf.Name()
+		}
+	}
+	if err != nil {
+		log.Printf("preprocessor %s: %v\n", m.FileName, err)
+		return nil, nil
+	}
+	if pf == nil {
+		return nil, nil
+	}
+	log.Printf("preprocessed %s with %s\n", m.FileName, command)
+	return pf, r
+}
+
+// preprocessorCmd returns the command that runs the command line by the shell.
+// "%s" is passed as the positional parameters so that the names are not interpreted by the shell.
+func preprocessorCmd(command string, names ...string) *exec.Cmd {
+	for i := range names {
+		command = strings.Replace(command, "%s", fmt.Sprintf(`"$%d"`, i+1), 1)
+	}
+	args := append([]string{"-c", command, PreprocessorShell}, names...)
+	return exec.Command(PreprocessorShell, args...)
+}
+
+// runPipePreprocessor runs the command and returns the read end of its standard output.
+// It is the "|command %s" form of LESSOPEN, which returns nil if the command outputs nothing.
+// In the "||command %s" form, the exit status decides the empty output:
+// the empty output is returned if the command succeeds, and nil if it fails.
+func runPipePreprocessor(command string, fileName string) (*os.File, io.Reader, error) {
+	useStatus := false
+	if c, ok := strings.CutPrefix(command, "|"); ok {
+		command, useStatus = c, true
+	}
+	// The "-" form also accepts the standard input, which is never preprocessed.
+	command = strings.TrimPrefix(command, "-")
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	c := preprocessorCmd(command, fileName)
+	c.Stdout = pw
+	if err := c.Start(); err != nil {
+		closeFile(pr)
+		closeFile(pw)
+		return nil, nil, err
+	}
+	closeFile(pw)
+	// Read the first byte to know whether the command outputs anything.
+	buf := make([]byte, 1)
+	if _, err := io.ReadFull(pr, buf); err == nil {
+		go func() {
+			if err := c.Wait(); err != nil {
+				log.Printf("preprocessor %s: %v\n", fileName, err)
+			}
+		}()
+		return pr, io.MultiReader(bytes.NewReader(buf), pr), nil
+	}
+	err = c.Wait()
+	if useStatus && err == nil {
+		return pr, pr, nil
+	}
+	closeFile(pr)
+	if err != nil {
+		log.Printf("preprocessor %s: %v\n", fileName, err)
+	}
+	return nil, nil, nil
+}
+
+// runFilePreprocessor runs the command and opens the file whose name is the output of the command.
+// It is the "command %s" form of LESSOPEN.
+func runFilePreprocessor(command string, fileName string) (*os.File, error) {
+	out, err := preprocessorCmd(command, fileName).Output()
+	if err != nil {
+		return nil, err
+	}
+	replacement := strings.TrimSpace(string(out))
+	if replacement == "" || replacement == fileName {
+		return nil, nil
+	}
+	return os.Open(replacement)
+}
+
+// runLessClose runs LESSCLOSE with the file name and the replacement file name
+// after the replacement file of the preprocessor is closed.
+func (m *Document) runLessClose() {
+	replacement := m.replacement
+	if replacement == "" {
+		return
+	}
+	m.replacement = ""
+	lessClose := os.Getenv("LESSCLOSE")
+	if lessClose == "" {
+		return
+	}
+	if err := preprocessorCmd(lessClose, m.FileName, replacement).Run(); err != nil {
+		log.Printf("LESSCLOSE %s: %v\n", m.FileName, err)
+	}
+}
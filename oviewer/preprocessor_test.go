@@ -0,0 +1,180 @@
+package oviewer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPreprocessor_match(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		p         Preprocessor
+		fileName  string
+		mimeType  string
+		wantMatch bool
+	}{
+		{name: "all files", p: Preprocessor{}, fileName: "a.txt", wantMatch: true},
+		{name: "extension", p: Preprocessor{Extensions: []string{".pdf"}}, fileName: "a.PDF", wantMatch: true},
+		{name: "extension without dot", p: Preprocessor{Extensions: []string{"pdf"}}, fileName: "a.pdf", wantMatch: true},
+		{name: "other extension", p: Preprocessor{Extensions: []string{".pdf"}}, fileName: "a.txt", wantMatch: false},
+		{name: "MIME type", p: Preprocessor{MIMETypes: []string{"application/pdf"}}, fileName: "a", mimeType: "application/pdf", wantMatch: true},
+		{name: "MIME wildcard", p: Preprocessor{MIMETypes: []string{"image/*"}}, fileName: "a", mimeType: "image/png", wantMatch: true},
+		{name: "other MIME type", p: Preprocessor{MIMETypes: []string{"image/*"}}, fileName: "a", mimeType: "text/plain", wantMatch: false},
+		{name: "unknown MIME type", p: Preprocessor{MIMETypes: []string{"image/*"}}, fileName: "a", wantMatch: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.p.match(tt.fileName, tt.mimeType); got != tt.wantMatch {
+				t.Errorf("Preprocessor.match() = %v, want %v", got, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestDocument_preprocess(t *testing.T) {
+	tmpDir := t.TempDir()
+	replacement := filepath.Join(tmpDir, "replacement.txt")
+	if err := os.WriteFile(replacement, []byte("replaced\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name          string
+		preprocessors []Preprocessor
+		lessOpen      string
+		want          string
+		wantEmpty     bool
+		wantPre       bool
+	}{
+		{
+			name:          "pipe",
+			preprocessors: []Preprocessor{{Command: "|tr a-z A-Z < %s", Extensions: []string{".txt"}}},
+			want:          "TEST",
+			wantPre:       true,
+		},
+		{
+			name:          "MIME type",
+			preprocessors: []Preprocessor{{Command: "|echo text", MIMETypes: []string{"text/*"}}},
+			want:          "text",
+			wantPre:       true,
+		},
+		{
+			name:          "not matched",
+			preprocessors: []Preprocessor{{Command: "|echo pdf", Extensions: []string{".pdf"}}},
+			want:          "test",
+		},
+		{
+			name:          "pipe empty output",
+			preprocessors: []Preprocessor{{Command: "|true %s"}},
+			want:          "test",
+		},
+		{
+			name:          "status empty output",
+			preprocessors: []Preprocessor{{Command: "||true %s"}},
+			wantEmpty:     true,
+			wantPre:       true,
+		},
+		{
+			name:          "status empty output failed",
+			preprocessors: []Preprocessor{{Command: "||false %s"}},
+			want:          "test",
+		},
+		{
+			name:          "status output failed",
+			preprocessors: []Preprocessor{{Command: "||echo text; false %s"}},
+			want:          "text",
+			wantPre:       true,
+		},
+		{
+			name:     "LESSOPEN pipe",
+			lessOpen: "| tr a-z A-Z < %s",
+			want:     "TEST",
+			wantPre:  true,
+		},
+		{
+			name:     "LESSOPEN replacement file",
+			lessOpen: "echo " + replacement + " # %s",
+			want:     "replaced",
+			wantPre:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Preprocessors = tt.preprocessors
+			LessOpen = tt.lessOpen != ""
+			t.Setenv("LESSOPEN", tt.lessOpen)
+			defer func() {
+				Preprocessors = nil
+				LessOpen = false
+			}()
+			m := docFileReadHelper(t, filepath.Join(testdata, "test.txt"))
+			if tt.wantEmpty {
+				if got := m.BufEndNum(); got != 0 {
+					t.Errorf("BufEndNum() = %d, want 0", got)
+				}
+			} else if got := m.LineString(0); got != tt.want {
+				t.Errorf("LineString() = %q, want %q", got, tt.want)
+			}
+			if got := m.preprocessed.Load(); got != tt.wantPre {
+				t.Errorf("preprocessed = %v, want %v", got, tt.wantPre)
+			}
+		})
+	}
+}
+
+func TestDocument_preprocess_lessClose(t *testing.T) {
+	tmpDir := t.TempDir()
+	replacement := filepath.Join(tmpDir, "replacement.txt")
+	if err := os.WriteFile(replacement, []byte("replaced\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	closed := filepath.Join(tmpDir, "closed")
+	LessOpen = true
+	t.Setenv("LESSOPEN", "echo "+replacement+" # %s")
+	t.Setenv("LESSCLOSE", "echo %s %s > "+closed)
+	defer func() {
+		LessOpen = false
+	}()
+	m := docFileReadHelper(t, filepath.Join(testdata, "test.txt"))
+	if got := m.LineString(0); got != "replaced" {
+		t.Fatalf("LineString() = %q, want %q", got, "replaced")
+	}
+	// LESSCLOSE is not run while the replacement file is read.
+	if _, err := os.Stat(closed); err == nil {
+		t.Fatal("LESSCLOSE was run before the document is closed")
+	}
+	m.requestClose()
+	buf, err := os.ReadFile(closed)
+	if err != nil {
+		t.Fatalf("LESSCLOSE was not run: %v", err)
+	}
+	want := filepath.Join(testdata, "test.txt") + " " + replacement + "\n"
+	if string(buf) != want {
+		t.Errorf("LESSCLOSE arguments = %q, want %q", buf, want)
+	}
+}
+
+func TestDocument_preprocess_reload(t *testing.T) {
+	Preprocessors = []Preprocessor{{Command: "|tr a-z A-Z < %s"}}
+	defer func() {
+		Preprocessors = nil
+	}()
+	m := docFileReadHelper(t, filepath.Join(testdata, "test.txt"))
+	if m.seekable {
+		t.Fatal("seekable = true, want false for the output of the preprocessor")
+	}
+	// The file itself is read after the preprocessor no longer matches.
+	Preprocessors = nil
+	if err := m.reload(); err != nil {
+		t.Fatal(err)
+	}
+	m.WaitEOF()
+	if !m.seekable || m.preprocessed.Load() {
+		t.Errorf("seekable = %v, preprocessed = %v, want true, false", m.seekable, m.preprocessed.Load())
+	}
+	if got := m.LineString(0); got != "test" {
+		t.Errorf("LineString() = %q, want %q", got, "test")
+	}
+}
@@ -0,0 +1,174 @@
+package oviewer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+)
+
+// promptMarkPrefix is the start of the OSC 133 escape sequence.
+const promptMarkPrefix = "\x1b]133;"
+
+// The states of the detection of the prompt marks.
+const (
+	promptUnknown int32 = iota
+	promptNotFound
+	promptFound
+)
+
+// linePromptMarks returns the contents of the line and the prompt marks in it.
+func linePromptMarks(str string) (contents, []promptMark) {
+	if !strings.Contains(str, promptMarkPrefix) {
+		return nil, nil
+	}
+	es := newESConverter()
+	lc := parseString(es, str, 0)
+	return lc, es.promptMarks
+}
+
+// isPromptStart returns true if the line has the mark of the prompt start.
+func isPromptStart(str string) bool {
+	_, marks := linePromptMarks(str)
+	for _, mark := range marks {
+		if mark.kind == 'A' {
+			return true
+		}
+	}
+	return false
+}
+
+// promptSearcher is a Searcher that matches the lines with the prompt start mark of OSC 133.
+// It is used as the section delimiter for the output of the shell with the shell integration.
+type promptSearcher struct{}
+
+// Match returns true if the line has the prompt start mark.
+func (promptSearcher) Match(target []byte) bool {
+	if !bytes.Contains(target, []byte(promptMarkPrefix)) {
+		return false
+	}
+	return isPromptStart(string(target))
+}
+
+// MatchString returns true if the line has the prompt start mark.
+func (promptSearcher) MatchString(target string) bool {
+	return isPromptStart(target)
+}
+
+// FindAll returns nil because the mark is not displayed.
+func (promptSearcher) FindAll(string) [][]int {
+	return nil
+}
+
+// String returns the name of the searcher.
+func (promptSearcher) String() string {
+	return "OSC 133"
+}
+
+// hasPromptMarks returns true if the prompt marks of OSC 133 have been found in the first lines of the document.
+// It only reads the state of the detection, which runs in the background
+// once each time a chunk is added and once the document is read to the end.
+func (m *Document) hasPromptMarks() bool {
+	if state := m.promptState.Load(); state != promptUnknown {
+		return state == promptFound
+	}
+	point := m.promptCheckPoint()
+	if m.promptChecked.Swap(point) != point {
+		go m.detectPromptMarks()
+	}
+	return false
+}
+
+// promptCheckPoint returns the number of the chunks read,
+// or -1 if the document has been read to the end.
+func (m *Document) promptCheckPoint() int64 {
+	if m.BufEOF() {
+		return -1
+	}
+	return int64(m.BufEndNum() / ChunkSize)
+}
+
+// detectPromptMarks looks for the prompt marks of OSC 133 in the first lines of the document.
+// The result is kept once the marks are found or enough lines are read.
+func (m *Document) detectPromptMarks() {
+	eof := m.BufEOF()
+	lines := m.sampleLines(context.Background(), detectSampleLines)
+	for _, line := range lines {
+		if strings.Contains(line, promptMarkPrefix) && isPromptStart(line) {
+			m.promptState.Store(promptFound)
+			// Draw again with the sections of the prompt marks.
+			atomic.StoreInt32(&m.store.changed, 1)
+			return
+		}
+	}
+	if len(lines) >= detectSampleLines || eof {
+		m.promptState.Store(promptNotFound)
+	}
+}
+
+// promptSections returns the list of the commands marked by OSC 133.
+// Each line is the command line followed by the exit code.
+func (m *Document) promptSections(ctx context.Context, offset int) MatchedLineList {
+	var sections MatchedLineList
+	var exitCodes []string
+	err := m.eachLine(ctx, 0, func(lN int, line []byte) bool {
+		if !bytes.Contains(line, []byte(promptMarkPrefix)) {
+			return true
+		}
+		lc, marks := linePromptMarks(string(line))
+		for i, mark := range marks {
+			switch mark.kind {
+			case 'A':
+				sections = append(sections, MatchedLine{lineNum: lN + offset, line: promptCommand(lc, marks[i:])})
+				exitCodes = append(exitCodes, "")
+			case 'D':
+				if len(exitCodes) > 0 && exitCodes[len(exitCodes)-1] == "" {
+					exitCodes[len(exitCodes)-1] = mark.exitCode
+				}
+			}
+		}
+		return true
+	})
+	if err != nil {
+		log.Printf("failed to read prompt marks: %v\n", err)
+	}
+	for i, code := range exitCodes {
+		sections[i].line = append(sections[i].line, exitCodeLabel(code)...)
+	}
+	return sections
+}
+
+// promptCommand returns the command line after the prompt start mark.
+// The command after the command start mark is returned if it exists.
+func promptCommand(lc contents, marks []promptMark) []byte {
+	start, end := marks[0].x, len(lc)
+	for _, mark := range marks[1:] {
+		if mark.kind == 'A' {
+			end = mark.x
+			break
+		}
+		if mark.kind == 'B' {
+			start = mark.x
+		}
+		if mark.kind == 'C' || mark.kind == 'D' {
+			end = mark.x
+			break
+		}
+	}
+	str, _ := ContentsToStr(lc[start:max(start, end)])
+	return []byte(strings.TrimSpace(str))
+}
+
+// exitCodeLabel returns the label of the exit code.
+// The exit code other than 0 is displayed in red.
+func exitCodeLabel(code string) string {
+	switch code {
+	case "":
+		return ""
+	case "0":
+		return " [0]"
+	}
+	return fmt.Sprintf(" \x1b[31m[%s]\x1b[0m", code)
+}
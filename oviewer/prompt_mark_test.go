@@ -0,0 +1,148 @@
+package oviewer
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_linePromptMarks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		str  string
+		want []promptMark
+	}{
+		{
+			name: "no marks",
+			str:  "$ ls",
+			want: nil,
+		},
+		{
+			name: "prompt and command",
+			str:  "\x1b]133;A\a$ \x1b]133;B\x1b\\ls",
+			want: []promptMark{
+				{kind: 'A', x: 0},
+				{kind: 'B', x: 2},
+			},
+		},
+		{
+			name: "exit code",
+			str:  "\x1b]133;D;1\a\x1b]133;A\a$ ",
+			want: []promptMark{
+				{kind: 'D', x: 0, exitCode: "1"},
+				{kind: 'A', x: 0},
+			},
+		},
+		{
+			name: "other osc",
+			str:  "\x1b]133;P;k=i\a\x1b]8;;https://example.com\alink",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, got := linePromptMarks(tt.str)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("linePromptMarks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_promptSearcher(t *testing.T) {
+	t.Parallel()
+	s := promptSearcher{}
+	if !s.Match([]byte("\x1b]133;A\a$ ls")) {
+		t.Error("Match() = false, want true")
+	}
+	if s.Match([]byte("\x1b]133;C\aoutput")) {
+		t.Error("Match() = true, want false")
+	}
+	if s.MatchString("$ ls") {
+		t.Error("MatchString() = true, want false")
+	}
+}
+
+func TestDocument_promptSections(t *testing.T) {
+	t.Parallel()
+	m := docFileReadHelper(t, filepath.Join(testdata, "prompt.txt"))
+	m.detectPromptMarks()
+	if !m.hasPromptMarks() {
+		t.Fatal("hasPromptMarks() = false, want true")
+	}
+	got := m.promptSections(context.Background(), 0)
+	want := MatchedLineList{
+		{lineNum: 0, line: []byte("echo hello [0]")},
+		{lineNum: 2, line: []byte("false \x1b[31m[1]\x1b[0m")},
+		{lineNum: 3, line: []byte("ls [0]")},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("promptSections() = %q, want %q", got, want)
+	}
+}
+
+func TestDocument_nextSection_prompt(t *testing.T) {
+	t.Parallel()
+	m := docFileReadHelper(t, filepath.Join(testdata, "prompt.txt"))
+	m.detectPromptMarks()
+	ctx := context.Background()
+	tests := []struct {
+		lN   int
+		want int
+	}{
+		{lN: 0, want: 2},
+		{lN: 2, want: 3},
+	}
+	for _, tt := range tests {
+		got, err := m.nextSection(ctx, tt.lN)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("nextSection(%d) = %d, want %d", tt.lN, got, tt.want)
+		}
+	}
+	if _, err := m.nextSection(ctx, 3); err == nil {
+		t.Error("nextSection(3) error = nil, want error")
+	}
+	got, err := m.prevSection(ctx, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 2 {
+		t.Errorf("prevSection(3) = %d, want 2", got)
+	}
+}
+
+func TestDocument_hasPromptMarks_notFound(t *testing.T) {
+	t.Parallel()
+	m := docHelper(t, "$ ls\na.txt\n")
+	m.detectPromptMarks()
+	if m.hasPromptMarks() {
+		t.Error("hasPromptMarks() = true, want false")
+	}
+	if m.sectionEnabled() {
+		t.Error("sectionEnabled() = true, want false")
+	}
+}
+
+func TestDocument_hasPromptMarks_background(t *testing.T) {
+	t.Parallel()
+	m := docFileReadHelper(t, filepath.Join(testdata, "prompt.txt"))
+	// The lines are not read on the call, but in the background.
+	timeout := time.After(5 * time.Second)
+	for !m.hasPromptMarks() {
+		select {
+		case <-timeout:
+			t.Fatal("timeout waiting for the detection of the prompt marks")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if m.promptChecked.Load() != m.promptCheckPoint() {
+		t.Errorf("promptChecked = %v, want %v", m.promptChecked.Load(), m.promptCheckPoint())
+	}
+}
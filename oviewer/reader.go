@@ -246,6 +246,7 @@ func (m *Document) reloadFile(reader *bufio.Reader) (*bufio.Reader, error) {
 	if err := m.file.Close(); err != nil {
 		log.Printf("reload: %v\n", err)
 	}
+	m.runLessClose()
 	m.ClearCache()
 
 	atomic.StoreInt32(&m.closed, 0)
@@ -289,16 +290,32 @@ func (m *Document) openFileReader(fileName string) (io.Reader, error) {
 // fileReader returns a io.Reader.
 // selects a reader according to compression type and returns io.Reader.
 func (m *Document) fileReader(f *os.File) (io.Reader, error) {
+	// The file itself is read again if the preprocessor made it unseekable before.
+	if m.preprocessed.Load() && !m.WatchMode {
+		m.seekable = isSeekable(f)
+	}
+	// The preprocessor runs before locking the store because it waits for the command.
+	pf, pr := m.preprocess(f)
+
 	m.store.mu.Lock()
 	defer m.store.mu.Unlock()
 
 	atomic.StoreInt32(&m.closed, 0)
 	m.file = f
 
-	cFormat := UNCOMPRESSED
 	r := io.Reader(m.file)
+	// The output of the preprocessor is read like a pipe.
+	m.preprocessed.Store(pf != nil)
+	if pf != nil {
+		closeFile(f)
+		m.file = pf
+		m.seekable = false
+		r = pr
+	}
+
+	cFormat := UNCOMPRESSED
 	if !SkipExtract {
-		cFormat, r = uncompressedReader(m.file, m.seekable)
+		cFormat, r = uncompressedReader(r, m.seekable)
 	}
 
 	if cFormat == UNCOMPRESSED {
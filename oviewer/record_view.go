@@ -0,0 +1,98 @@
+package oviewer
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// recordField is a column name and its value of the record.
+type recordField struct {
+	name  string
+	value string
+}
+
+// record returns the fields of the line in the display order.
+// The column names come from the header, falling back to the column indexes.
+func (m *Document) record(line string) []recordField {
+	values := m.columnValues(line)
+	names := m.headerColumnNames()
+	columns := make([]string, len(values))
+	for i := range values {
+		if i < len(names) && names[i] != "" {
+			columns[i] = names[i]
+			continue
+		}
+		columns[i] = strconv.Itoa(i)
+	}
+	values = m.displayColumnValues(values)
+	columns = m.displayColumnValues(columns)
+	fields := make([]recordField, 0, len(values))
+	for i, value := range values {
+		fields = append(fields, recordField{name: columns[i], value: trimColumnValue(value)})
+	}
+	return fields
+}
+
+// recordLineNumber returns the line number of the current row, the first line of the body.
+func (root *Root) recordLineNumber() int {
+	m := root.Doc
+	if m.SectionHeader {
+		return root.firstBodyLine()
+	}
+	return m.topLN + m.firstLine()
+}
+
+// sidebarItemsForRecord returns SidebarItems for the record view of the current row.
+// The names are used as labels so that they stay visible when scrolling the values.
+func (root *Root) sidebarItemsForRecord() []SidebarItem {
+	var items []SidebarItem
+	length := root.sidebarWidth - 4
+	m := root.Doc
+	lN := root.recordLineNumber()
+	line, err := m.Line(lN)
+	if err != nil {
+		items = append(items, root.sidebarItemForText("no line", length))
+		return items
+	}
+	fields := m.record(string(line))
+	nameWidth := 0
+	for _, f := range fields {
+		nameWidth = max(nameWidth, len(StrToContents(f.name, 0)))
+	}
+	nameWidth = min(nameWidth, root.sidebarWidth/2)
+
+	totalLines := len(fields) + 1
+	root.adjustSidebarScroll(SidebarModeRecord, totalLines, 0)
+	scroll := root.sidebarScrolls[SidebarModeRecord]
+	start := scroll.y
+	end := min(start+root.scr.vHeight, totalLines)
+	for i := start; i < end; i++ {
+		if i == 0 {
+			items = append(items, root.sidebarItemForText("line "+strconv.Itoa(lN-m.firstLine()+1), length))
+			continue
+		}
+		f := fields[i-1]
+		label := StrToContents(f.name, 0)
+		if len(label) > nameWidth {
+			label = label[:nameWidth]
+		}
+		name := label.String() + strings.Repeat(" ", nameWidth-len(label)) + ": "
+		lc := StrToContents(f.value, 0)
+		if len(lc) < length {
+			spaces := StrToContents(strings.Repeat(" ", length-len(lc)), 0)
+			lc = append(lc, spaces...)
+		}
+		items = append(items, SidebarItem{
+			Label:     name,
+			Contents:  lc,
+			IsCurrent: false,
+		})
+	}
+	return items
+}
+
+// toggleSidebarRecord toggles the record view of the current row in the sidebar.
+func (root *Root) toggleSidebarRecord(ctx context.Context) {
+	root.toggleSidebar(ctx, SidebarModeRecord)
+}
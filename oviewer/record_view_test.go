@@ -0,0 +1,94 @@
+package oviewer
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+func TestDocument_record(t *testing.T) {
+	tests := []struct {
+		name   string
+		header int
+		hide   []string
+		line   string
+		want   []recordField
+	}{
+		{
+			name:   "header names",
+			header: 1,
+			line:   "b,1.5K,10",
+			want: []recordField{
+				{name: "name", value: "b"},
+				{name: "size", value: "1.5K"},
+				{name: "count", value: "10"},
+			},
+		},
+		{
+			name:   "column indexes",
+			header: 0,
+			line:   "b,1.5K,10",
+			want: []recordField{
+				{name: "0", value: "b"},
+				{name: "1", value: "1.5K"},
+				{name: "2", value: "10"},
+			},
+		},
+		{
+			name:   "more values than names",
+			header: 1,
+			line:   "b,1.5K,10,x",
+			want: []recordField{
+				{name: "name", value: "b"},
+				{name: "size", value: "1.5K"},
+				{name: "count", value: "10"},
+				{name: "3", value: "x"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := docFileReadHelper(t, filepath.Join(testdata, "sort.csv"))
+			m.ColumnMode = true
+			m.ColumnDelimiter = ","
+			m.Header = tt.header
+			m.regexpCompile()
+			if got := m.record(tt.line); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Document.record() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoot_sidebarItemsForRecord(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "sort.csv"))
+	root.Doc.WaitEOF()
+	m := root.Doc
+	m.ColumnMode = true
+	m.ColumnDelimiter = ","
+	m.Header = 1
+	m.regexpCompile()
+	root.prepareScreen()
+	root.sidebarWidth = 30
+
+	items := root.sidebarItemsForRecord()
+	if len(items) != 4 {
+		t.Fatalf("sidebarItemsForRecord() len = %v, want 4", len(items))
+	}
+	if items[1].Label != "name : " || items[1].Contents.String()[:1] != "b" {
+		t.Errorf("sidebarItemsForRecord() first = %q %q", items[1].Label, items[1].Contents.String())
+	}
+
+	// The next row keeps the record view.
+	m.topLN = 1
+	items = root.sidebarItemsForRecord()
+	if items[1].Contents.String()[:1] != "d" {
+		t.Errorf("sidebarItemsForRecord() next = %q", items[1].Contents.String())
+	}
+}
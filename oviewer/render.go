@@ -2,6 +2,7 @@ package oviewer
 
 import (
 	"io"
+	"sync/atomic"
 
 	"github.com/noborus/ov/biomap"
 )
@@ -22,3 +23,46 @@ func renderDoc(parent *Document, reader io.Reader) (*Document, error) {
 	}
 	return doc, nil
 }
+
+// renderDirectDoc returns a new document for rendering the parent
+// whose lines are written directly with appendRenderLine instead of a reader,
+// so that the lines at the end can be replaced.
+func renderDirectDoc(parent *Document) (*Document, error) {
+	doc, err := NewDocument()
+	if err != nil {
+		return nil, err
+	}
+	doc.parent = parent
+	doc.lineNumMap = biomap.NewMap[int, int]()
+	doc.preventReload = true
+	doc.seekable = false
+	doc.memoryLimit = loadChunksCapacity(false)
+	doc.store.setNewLoadChunks(doc.memoryLimit)
+	if err := doc.ControlRender(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// appendRenderLine appends the line to the document created by renderDirectDoc.
+func (m *Document) appendRenderLine(line []byte) {
+	s := m.store
+	chunk := s.chunkForAdd(false, s.size)
+	s.append(chunk, true, append(line, '\n'))
+	atomic.StoreInt32(&s.changed, 1)
+}
+
+// truncateRenderLines removes the lines from end of the document created by renderDirectDoc.
+func (m *Document) truncateRenderLines(end int) {
+	m.store.truncate(end)
+	m.cache.Purge()
+	atomic.StoreInt32(&m.store.changed, 1)
+}
+
+// renderEOF marks the end of the document created by renderDirectDoc.
+func (m *Document) renderEOF() {
+	atomic.StoreInt32(&m.store.eof, 1)
+	m.cond.L.Lock()
+	m.cond.Broadcast()
+	m.cond.L.Unlock()
+}
@@ -11,10 +11,17 @@ type RunTimeSettings struct {
 	Converter string
 	// Caption is an additional caption to display after the file name.
 	Caption string
+	// Syntax is the language of the syntax highlighting, or "auto" to select it by the file extension.
+	Syntax string
 	// ColumnDelimiterReg is a compiled regular expression of ColumnDelimiter.
 	ColumnDelimiterReg *regexp.Regexp
 	// ColumnDelimiter is a column delimiter.
 	ColumnDelimiter string
+	// ColumnRegexpReg is a compiled regular expression of ColumnRegexp.
+	ColumnRegexpReg *regexp.Regexp
+	// ColumnRegexp is a regular expression with named groups that splits the columns.
+	// If it is set, it takes precedence over ColumnDelimiter.
+	ColumnRegexp string
 	// SectionDelimiterReg is a section delimiter.
 	SectionDelimiterReg *regexp.Regexp
 	// SectionDelimiter is a section delimiter.
@@ -23,6 +30,17 @@ type RunTimeSettings struct {
 	JumpTarget string
 	// MultiColorWords specifies words to color separated by spaces.
 	MultiColorWords []string
+	// ColumnHide specifies the columns to hide by index or header name (align mode only).
+	ColumnHide []string
+	// ColumnOrder specifies the columns to display first by index or header name (align mode only).
+	ColumnOrder []string
+	// ColumnTransform specifies the display transforms of the columns as "column:transform" (align mode only).
+	ColumnTransform []string
+	// ColumnNames specifies the names of the columns for data without a header line.
+	ColumnNames []string
+	// LogfmtKeys specifies the logfmt keys to display as columns.
+	// If empty, the keys are entered at the prompt.
+	LogfmtKeys []string
 
 	// TabWidth is tab stop num.
 	TabWidth int
@@ -59,6 +77,13 @@ type RunTimeSettings struct {
 	ColumnWidth bool
 	// ColumnRainbow is column rainbow.
 	ColumnRainbow bool
+	// AlignStable computes the column widths of align mode over the whole document.
+	AlignStable bool
+	// AlignSample is the number of lines to compute the column widths with AlignStable.
+	// If 0 is specified, all lines are computed.
+	AlignSample int
+	// ApplyTransform applies the column transforms also to search, filter and export.
+	ApplyTransform bool
 	// LineNumMode displays line numbers.
 	LineNumMode bool
 	// WrapMode is wrap mode.
@@ -77,6 +102,10 @@ type RunTimeSettings struct {
 	SectionHeader bool
 	// HideOtherSection is whether to hide other sections.
 	HideOtherSection bool
+	// DiffMode is whether to display the unified diff with styles and sections.
+	DiffMode bool
+	// ManMode is whether to navigate the formatted man page by headings, options and references.
+	ManMode bool
 	// StatusLine is whether to display the status line.
 	StatusLine bool
 
@@ -133,6 +162,44 @@ type Style struct {
 	SelectCopied OVStyle
 	// PauseLine is the style that applies to the line where follow mode is paused.
 	PauseLine OVStyle
+	// ColumnUnmatched is the style that applies to the line that does not match ColumnRegexp.
+	ColumnUnmatched OVStyle
+	// DiffAdded is the style that applies to the added lines in the diff mode.
+	DiffAdded OVStyle
+	// DiffRemoved is the style that applies to the removed lines in the diff mode.
+	DiffRemoved OVStyle
+	// DiffFile is the style that applies to the file headers in the diff mode.
+	DiffFile OVStyle
+	// DiffHunk is the style that applies to the hunk headers in the diff mode.
+	DiffHunk OVStyle
+	// DiffWord is the style that applies to the changed words in the diff mode.
+	DiffWord OVStyle
+	// SyntaxKeyword is the style that applies to the keywords in the syntax highlighting.
+	SyntaxKeyword OVStyle
+	// SyntaxString is the style that applies to the strings in the syntax highlighting.
+	SyntaxString OVStyle
+	// SyntaxNumber is the style that applies to the numbers in the syntax highlighting.
+	SyntaxNumber OVStyle
+	// SyntaxComment is the style that applies to the comments in the syntax highlighting.
+	SyntaxComment OVStyle
+	// SyntaxKey is the style that applies to the keys of YAML, JSON and TOML in the syntax highlighting.
+	SyntaxKey OVStyle
+	// MarkdownHeading is the style that applies to the headings by level in the markdown converter.
+	MarkdownHeading []OVStyle
+	// MarkdownCode is the style that applies to the code spans and the code blocks in the markdown converter.
+	MarkdownCode OVStyle
+	// MarkdownLink is the style that applies to the links in the markdown converter.
+	MarkdownLink OVStyle
+	// MarkdownMark is the style that applies to the bullets, quote bars, rules and table borders in the markdown converter.
+	MarkdownMark OVStyle
+	// JSONKey is the style that applies to the object keys in the json converter.
+	JSONKey OVStyle
+	// JSONString is the style that applies to the string values in the json converter.
+	JSONString OVStyle
+	// JSONNumber is the style that applies to the number values in the json converter.
+	JSONNumber OVStyle
+	// JSONLiteral is the style that applies to true, false and null in the json converter.
+	JSONLiteral OVStyle
 }
 
 // The name of the converter that can be specified.
@@ -141,6 +208,9 @@ const (
 	convRaw      string = "raw"      // convRaw is displayed without processing escape sequences as they are.
 	convAlign    string = "align"    // convAlign is aligned in each column.
 	convWordWrap string = "wordwrap" // convWordWrap is wrapped at word boundaries.
+	convJSON     string = "json"     // convJSON pretty-prints JSON Lines.
+	convVT       string = "vt"       // convVT displays the screen rendered by the virtual terminal.
+	convMarkdown string = "markdown" // convMarkdown renders markdown.
 )
 
 const (
@@ -223,6 +293,70 @@ func NewStyle() Style {
 		PauseLine: OVStyle{
 			Background: "#663333",
 		},
+		ColumnUnmatched: OVStyle{
+			Dim: true,
+		},
+		DiffAdded: OVStyle{
+			Foreground: "green",
+		},
+		DiffRemoved: OVStyle{
+			Foreground: "red",
+		},
+		DiffFile: OVStyle{
+			Foreground: "yellow",
+			Bold:       true,
+		},
+		DiffHunk: OVStyle{
+			Foreground: "aqua",
+		},
+		DiffWord: OVStyle{
+			Reverse: true,
+		},
+		SyntaxKeyword: OVStyle{
+			Foreground: "fuchsia",
+		},
+		SyntaxString: OVStyle{
+			Foreground: "green",
+		},
+		SyntaxNumber: OVStyle{
+			Foreground: "aqua",
+		},
+		SyntaxComment: OVStyle{
+			Foreground: "gray",
+		},
+		SyntaxKey: OVStyle{
+			Foreground: "yellow",
+		},
+		MarkdownHeading: []OVStyle{
+			{Foreground: "yellow", Bold: true, Underline: true},
+			{Foreground: "yellow", Bold: true},
+			{Foreground: "aqua", Bold: true},
+			{Bold: true},
+		},
+		MarkdownCode: OVStyle{
+			Foreground: "#e0e0e0",
+			Background: "#303030",
+		},
+		MarkdownLink: OVStyle{
+			Foreground: "blue",
+			Underline:  true,
+		},
+		MarkdownMark: OVStyle{
+			Foreground: "gray",
+		},
+		JSONKey: OVStyle{
+			Foreground: "blue",
+			Bold:       true,
+		},
+		JSONString: OVStyle{
+			Foreground: "green",
+		},
+		JSONNumber: OVStyle{
+			Foreground: "aqua",
+		},
+		JSONLiteral: OVStyle{
+			Foreground: "fuchsia",
+		},
 	}
 }
 
@@ -322,6 +456,9 @@ func updateRunTimeSettings(base RunTimeSettings, override General) RunTimeSettin
 	applyIfSet(&base.ColumnMode, override.ColumnMode)
 	applyIfSet(&base.ColumnWidth, override.ColumnWidth)
 	applyIfSet(&base.ColumnRainbow, override.ColumnRainbow)
+	applyIfSet(&base.AlignStable, override.AlignStable)
+	applyIfSet(&base.AlignSample, override.AlignSample)
+	applyIfSet(&base.ApplyTransform, override.ApplyTransform)
 	applyIfSet(&base.LineNumMode, override.LineNumMode)
 	applyIfSet(&base.WrapMode, override.WrapMode)
 	applyIfSet(&base.FollowMode, override.FollowMode)
@@ -331,12 +468,21 @@ func updateRunTimeSettings(base RunTimeSettings, override General) RunTimeSettin
 	applyIfSet(&base.PlainMode, override.PlainMode)
 	applyIfSet(&base.SectionHeader, override.SectionHeader)
 	applyIfSet(&base.HideOtherSection, override.HideOtherSection)
+	applyIfSet(&base.DiffMode, override.DiffMode)
+	applyIfSet(&base.ManMode, override.ManMode)
 	applyIfSet(&base.StatusLine, override.StatusLine)
 	applyIfSet(&base.ColumnDelimiter, override.ColumnDelimiter)
+	applyIfSet(&base.ColumnRegexp, override.ColumnRegexp)
 	applyIfSet(&base.SectionDelimiter, override.SectionDelimiter)
 	applyIfSet(&base.JumpTarget, override.JumpTarget)
 	applyIfSet(&base.MultiColorWords, override.MultiColorWords)
+	applyIfSet(&base.ColumnHide, override.ColumnHide)
+	applyIfSet(&base.ColumnOrder, override.ColumnOrder)
+	applyIfSet(&base.ColumnTransform, override.ColumnTransform)
+	applyIfSet(&base.ColumnNames, override.ColumnNames)
+	applyIfSet(&base.LogfmtKeys, override.LogfmtKeys)
 	applyIfSet(&base.Caption, override.Caption)
+	applyIfSet(&base.Syntax, override.Syntax)
 	applyIfSet(&base.Converter, override.Converter)
 	if override.Align != nil && *override.Align {
 		base.Converter = convAlign
@@ -395,5 +541,24 @@ func updateRuntimeStyle(base Style, override StyleConfig) Style {
 	applyIfSet(&base.SelectActive, override.SelectActive)
 	applyIfSet(&base.SelectCopied, override.SelectCopied)
 	applyIfSet(&base.PauseLine, override.PauseLine)
+	applyIfSet(&base.ColumnUnmatched, override.ColumnUnmatched)
+	applyIfSet(&base.DiffAdded, override.DiffAdded)
+	applyIfSet(&base.DiffRemoved, override.DiffRemoved)
+	applyIfSet(&base.DiffFile, override.DiffFile)
+	applyIfSet(&base.DiffHunk, override.DiffHunk)
+	applyIfSet(&base.DiffWord, override.DiffWord)
+	applyIfSet(&base.SyntaxKeyword, override.SyntaxKeyword)
+	applyIfSet(&base.SyntaxString, override.SyntaxString)
+	applyIfSet(&base.SyntaxNumber, override.SyntaxNumber)
+	applyIfSet(&base.SyntaxComment, override.SyntaxComment)
+	applyIfSet(&base.SyntaxKey, override.SyntaxKey)
+	applyIfSet(&base.MarkdownHeading, override.MarkdownHeading)
+	applyIfSet(&base.MarkdownCode, override.MarkdownCode)
+	applyIfSet(&base.MarkdownLink, override.MarkdownLink)
+	applyIfSet(&base.MarkdownMark, override.MarkdownMark)
+	applyIfSet(&base.JSONKey, override.JSONKey)
+	applyIfSet(&base.JSONString, override.JSONString)
+	applyIfSet(&base.JSONNumber, override.JSONNumber)
+	applyIfSet(&base.JSONLiteral, override.JSONLiteral)
 	return base
 }
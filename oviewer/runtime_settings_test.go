@@ -477,6 +477,9 @@ func Test_updateRuntimeSettings_AllFields(t *testing.T) {
 					ColumnMode:           boolPtr(false),
 					ColumnWidth:          boolPtr(false),
 					ColumnRainbow:        boolPtr(false),
+					AlignStable:          boolPtr(true),
+					AlignSample:          intPtr(100),
+					ApplyTransform:       boolPtr(true),
 					LineNumMode:          boolPtr(false),
 					WrapMode:             boolPtr(false),
 					FollowMode:           boolPtr(false),
@@ -511,6 +514,9 @@ func Test_updateRuntimeSettings_AllFields(t *testing.T) {
 				ColumnMode:           false,
 				ColumnWidth:          false,
 				ColumnRainbow:        false,
+				AlignStable:          true,
+				AlignSample:          100,
+				ApplyTransform:       true,
 				LineNumMode:          false,
 				WrapMode:             false,
 				FollowMode:           false,
@@ -775,6 +781,25 @@ func Test_updateRuntimeStyle(t *testing.T) {
 					SelectActive:         &blueStyle,
 					SelectCopied:         &blueStyle,
 					PauseLine:            &blueStyle,
+					ColumnUnmatched:      &blueStyle,
+					DiffAdded:            &blueStyle,
+					DiffRemoved:          &blueStyle,
+					DiffFile:             &blueStyle,
+					DiffHunk:             &blueStyle,
+					DiffWord:             &blueStyle,
+					SyntaxKeyword:        &blueStyle,
+					SyntaxString:         &blueStyle,
+					SyntaxNumber:         &blueStyle,
+					SyntaxComment:        &blueStyle,
+					SyntaxKey:            &blueStyle,
+					MarkdownHeading:      &newRainbowStyles,
+					MarkdownCode:         &blueStyle,
+					MarkdownLink:         &blueStyle,
+					MarkdownMark:         &blueStyle,
+					JSONKey:              &blueStyle,
+					JSONString:           &blueStyle,
+					JSONNumber:           &blueStyle,
+					JSONLiteral:          &blueStyle,
 				},
 			},
 			want: Style{
@@ -797,6 +822,25 @@ func Test_updateRuntimeStyle(t *testing.T) {
 				SelectActive:         blueStyle,
 				SelectCopied:         blueStyle,
 				PauseLine:            blueStyle,
+				ColumnUnmatched:      blueStyle,
+				DiffAdded:            blueStyle,
+				DiffRemoved:          blueStyle,
+				DiffFile:             blueStyle,
+				DiffHunk:             blueStyle,
+				DiffWord:             blueStyle,
+				SyntaxKeyword:        blueStyle,
+				SyntaxString:         blueStyle,
+				SyntaxNumber:         blueStyle,
+				SyntaxComment:        blueStyle,
+				SyntaxKey:            blueStyle,
+				MarkdownHeading:      newRainbowStyles,
+				MarkdownCode:         blueStyle,
+				MarkdownLink:         blueStyle,
+				MarkdownMark:         blueStyle,
+				JSONKey:              blueStyle,
+				JSONString:           blueStyle,
+				JSONNumber:           blueStyle,
+				JSONLiteral:          blueStyle,
 			},
 		},
 	}
@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 	"sync/atomic"
+	"time"
 	"unicode"
 
 	"codeberg.org/tslocum/cbind"
@@ -221,7 +222,14 @@ func (root *Root) createSearcher(word string, caseSensitive bool) Searcher {
 	if word == "" {
 		return nil
 	}
+	if searcher := root.columnSearcher(word, caseSensitive); searcher != nil {
+		return searcher
+	}
+	return root.transformSearcher(root.wordSearcher(word, caseSensitive))
+}
 
+// wordSearcher returns the Searcher of the word with the search settings.
+func (root *Root) wordSearcher(word string, caseSensitive bool) Searcher {
 	if root.Config.SmartCaseSensitive {
 		for _, ch := range word {
 			if unicode.IsUpper(ch) {
@@ -230,9 +238,11 @@ func (root *Root) createSearcher(word string, caseSensitive bool) Searcher {
 			}
 		}
 	}
+	if root.Config.NormalizeSearch || root.Config.FoldDiacritics {
+		return newNormalizeSearcher(word, caseSensitive, root.Config.RegexpSearch, root.Config.FoldDiacritics)
+	}
 	reg := regexpCompile(word, caseSensitive)
-	searcher := NewSearcher(word, reg, caseSensitive, root.Config.RegexpSearch)
-	return searcher
+	return NewSearcher(word, reg, caseSensitive, root.Config.RegexpSearch)
 }
 
 // setSearcher sets root.searcher using createSearcher and updates input.value.
@@ -247,11 +257,20 @@ func (root *Root) setSearcher(word string, caseSensitive bool) Searcher {
 	return searcher
 }
 
+// SearchProgressInterval is the interval for updating the search progress.
+var SearchProgressInterval = 200 * time.Millisecond
+
 // searchMove searches forward/backward and moves to the nearest matching line.
+// It returns true if the matching line is found.
+// In the background search, it returns false because the move happens after it returns.
 func (root *Root) searchMove(ctx context.Context, forward bool, lineNum int, searcher Searcher) bool {
 	if searcher == nil {
 		return false
 	}
+	if root.Config.SearchBackground {
+		root.searchMoveBackground(ctx, forward, lineNum, searcher)
+		return false
+	}
 	word := searcher.String()
 	root.setMessagef("search:%v (%v)Cancel", word, strings.Join(root.cancelKeys, ","))
 	eg, ctx := errgroup.WithContext(ctx)
@@ -262,13 +281,17 @@ func (root *Root) searchMove(ctx context.Context, forward bool, lineNum int, sea
 		return root.cancelWait(cancel)
 	})
 
+	m := root.Doc
 	eg.Go(func() error {
-		n, err := root.Doc.searchLine(ctx, searcher, forward, lineNum)
+		var progress atomic.Int64
+		stopProgress := root.startSearchProgress(ctx, m, word, forward, lineNum, &progress)
+		n, err := m.searchLine(ctx, searcher, forward, lineNum, &progress)
+		stopProgress()
 		root.sendSearchQuit()
 		if err != nil {
 			return fmt.Errorf("search:%w:%v", err, word)
 		}
-		root.sendSearchMove(n, searcher)
+		root.sendSearchMove(m, n, searcher)
 		return nil
 	})
 
@@ -280,16 +303,117 @@ func (root *Root) searchMove(ctx context.Context, forward bool, lineNum int, sea
 	return true
 }
 
+// searchMoveBackground searches forward/backward in the background
+// and moves to the nearest matching line when found.
+// The screen continues to be updated during the search.
+// It has its own cancel function so that it is not canceled by the incremental search.
+func (root *Root) searchMoveBackground(ctx context.Context, forward bool, lineNum int, searcher Searcher) {
+	word := searcher.String()
+	root.setMessagef("search:%v (%v)Cancel", word, strings.Join(root.cancelKeys, ","))
+	if root.searchCancelFunc != nil {
+		root.searchCancelFunc()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	root.searchCancelFunc = cancel
+	m := root.Doc
+	go func() {
+		defer cancel()
+		var progress atomic.Int64
+		stopProgress := root.startSearchProgress(ctx, m, word, forward, lineNum, &progress)
+		n, err := m.searchLine(ctx, searcher, forward, lineNum, &progress)
+		stopProgress()
+		if err != nil {
+			if errors.Is(err, ErrCancel) {
+				root.sendSearchProgress("cancel")
+				return
+			}
+			root.sendSearchProgress(fmt.Sprintf("search:%v:%v", err, word))
+			return
+		}
+		root.sendSearchMove(m, n, searcher)
+		root.sendSearchProgress(fmt.Sprintf("search:%v", word))
+	}()
+}
+
+// startSearchProgress starts sending the progress of the search of m from lineNum periodically.
+// progress is the line number being searched, updated by the search.
+// The returned function stops it and waits until no more progress is sent.
+func (root *Root) startSearchProgress(ctx context.Context, m *Document, word string, forward bool, lineNum int, progress *atomic.Int64) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(SearchProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				searched, total := m.searchProgressLines(int(progress.Load()), forward, lineNum)
+				root.sendSearchProgress(searchProgressMessage(word, searched, total, root.cancelKeys))
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// searchProgressLines returns the number of lines searched and the number of lines to search
+// from lineNum in the direction of the search, when current is the line being searched.
+func (m *Document) searchProgressLines(current int, forward bool, lineNum int) (int, int) {
+	if forward {
+		start := max(lineNum, m.BufStartNum())
+		return current - start, m.BufEndNum() - start
+	}
+	start := min(lineNum, m.BufEndNum()-1)
+	return start - current, start - m.BufStartNum() + 1
+}
+
+// searchProgressMessage returns a message representing the progress of the search.
+// current is the number of lines searched and total is the number of lines to search.
+func searchProgressMessage(word string, current int, total int, keys []string) string {
+	current = min(max(current, 0), total)
+	percent := 100
+	if total > 0 {
+		percent = current * 100 / total
+	}
+	return fmt.Sprintf("search:%v %d/%d (%d%%) (%v)Cancel", word, current, total, percent, strings.Join(keys, ","))
+}
+
+// eventSearchProgress represents the progress of the search.
+type eventSearchProgress struct {
+	tcell.EventTime
+	msg string
+}
+
+// sendSearchProgress fires the eventSearchProgress event.
+func (root *Root) sendSearchProgress(msg string) {
+	ev := &eventSearchProgress{}
+	ev.msg = msg
+	ev.SetEventNow()
+	root.postEvent(ev)
+}
+
 // searchLine is a forward/backward search wrap function.
-func (m *Document) searchLine(ctx context.Context, searcher Searcher, forward bool, lineNum int) (int, error) {
+// The line number being searched is stored in progress if it is not nil.
+func (m *Document) searchLine(ctx context.Context, searcher Searcher, forward bool, lineNum int, progress *atomic.Int64) (int, error) {
 	if forward {
-		return m.SearchLine(ctx, searcher, lineNum)
+		return m.searchLineMatch(ctx, searcher, lineNum, m.nonMatch, progress)
 	}
-	return m.BackSearchLine(ctx, searcher, lineNum)
+	return m.backSearchLine(ctx, searcher, lineNum, progress)
 }
 
 // Search searches for the search term and moves to the nearest matching line.
 func (m *Document) Search(ctx context.Context, searcher Searcher, chunkNum int, lineNum int) (int, error) {
+	return m.searchChunkMatch(ctx, searcher, chunkNum, lineNum, m.nonMatch)
+}
+
+// searchChunkMatch searches forward in the chunk from the specified line.
+// If nonMatch is true, it searches for the line that does not match.
+func (m *Document) searchChunkMatch(ctx context.Context, searcher Searcher, chunkNum int, lineNum int, nonMatch bool) (int, error) {
 	if !m.seekable {
 		if chunkNum != 0 && m.store.lastChunkNum() <= chunkNum {
 			m.requestLoad(chunkNum)
@@ -303,7 +427,7 @@ func (m *Document) Search(ctx context.Context, searcher Searcher, chunkNum int,
 		}
 	}
 
-	if m.nonMatch {
+	if nonMatch {
 		return m.SearchChunkNonMatch(ctx, searcher, chunkNum, lineNum)
 	}
 	return m.SearchChunk(ctx, searcher, chunkNum, lineNum)
@@ -408,11 +532,21 @@ func (m *Document) storageSearch(searcher Searcher, chunkNum int) bool {
 
 // SearchLine searches the document and returns the matching line number.
 func (m *Document) SearchLine(ctx context.Context, searcher Searcher, lineNum int) (int, error) {
+	return m.searchLineMatch(ctx, searcher, lineNum, m.nonMatch, nil)
+}
+
+// searchLineMatch searches the document forward and returns the matching line number.
+// If nonMatch is true, it returns the line number that does not match.
+// The line number being searched is stored in progress if it is not nil.
+func (m *Document) searchLineMatch(ctx context.Context, searcher Searcher, lineNum int, nonMatch bool, progress *atomic.Int64) (int, error) {
 	lineNum = max(lineNum, m.BufStartNum())
 	startChunk, sn := chunkLineNum(lineNum)
 
 	for cn := startChunk; ; cn++ {
-		n, err := m.Search(ctx, searcher, cn, sn)
+		if progress != nil {
+			progress.Store(int64(cn*ChunkSize + sn))
+		}
+		n, err := m.searchChunkMatch(ctx, searcher, cn, sn, nonMatch)
 		if err == nil {
 			return cn*ChunkSize + n, nil
 		}
@@ -435,10 +569,19 @@ func (m *Document) SearchLine(ctx context.Context, searcher Searcher, lineNum in
 
 // BackSearchLine does a backward search on the document and returns a matching line number.
 func (m *Document) BackSearchLine(ctx context.Context, searcher Searcher, lineNum int) (int, error) {
+	return m.backSearchLine(ctx, searcher, lineNum, nil)
+}
+
+// backSearchLine does a backward search on the document and returns a matching line number.
+// The line number being searched is stored in progress if it is not nil.
+func (m *Document) backSearchLine(ctx context.Context, searcher Searcher, lineNum int, progress *atomic.Int64) (int, error) {
 	lineNum = min(lineNum, m.BufEndNum()-1)
 	startChunk, sn := chunkLineNum(lineNum)
 	minChunk, _ := chunkLineNum(m.BufStartNum())
 	for cn := startChunk; cn >= minChunk; cn-- {
+		if progress != nil {
+			progress.Store(int64(cn*ChunkSize + sn))
+		}
 		n, err := m.BackSearch(ctx, searcher, cn, sn)
 		if err == nil {
 			return cn*ChunkSize + n, nil
@@ -481,6 +624,8 @@ func (root *Root) cancelWait(cancel context.CancelFunc) error {
 			return nil
 		case *eventUpdateEndNum:
 			root.updateEndNum()
+		case *eventSearchProgress:
+			root.setMessage(ev.msg)
 		case *eventReachEOF:
 			eventQueue = append(eventQueue, ev)
 		default:
@@ -517,13 +662,16 @@ func (root *Root) sendSearchQuit() {
 // eventSearchMove represents the move input mode.
 type eventSearchMove struct {
 	tcell.EventTime
+	m        *Document
 	ln       int
 	searcher Searcher
 }
 
-func (root *Root) sendSearchMove(lineNum int, searcher Searcher) {
+// sendSearchMove fires the eventSearchMove event to move to the line of the document searched.
+func (root *Root) sendSearchMove(m *Document, lineNum int, searcher Searcher) {
 	ev := &eventSearchMove{}
 	ev.SetEventNow()
+	ev.m = m
 	ev.ln = lineNum
 	ev.searcher = searcher
 	root.postEvent(ev)
@@ -542,13 +690,14 @@ func (root *Root) incSearch(ctx context.Context, forward bool) {
 
 	lineNum := root.startSearchLN()
 	ctx = root.cancelRestart(ctx)
+	m := root.Doc
 	go func() {
-		n, err := root.Doc.searchLine(ctx, searcher, forward, lineNum)
+		n, err := m.searchLine(ctx, searcher, forward, lineNum, nil)
 		if err != nil {
 			root.debugMessage(fmt.Sprintf("incSearch: %s", err))
 			return
 		}
-		root.sendSearchMove(n, searcher)
+		root.sendSearchMove(m, n, searcher)
 	}()
 }
 
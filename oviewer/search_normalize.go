@@ -0,0 +1,139 @@
+package oviewer
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// normalizeWord is a search that normalizes both the search word and the target
+// before matching. The actual matching is delegated to searcher,
+// which is created with the normalized search word.
+type normalizeWord struct {
+	searcher Searcher
+	word     string
+	fold     bool
+}
+
+// newNormalizeWord returns a Searcher that matches after normalization.
+func newNormalizeWord(word string, searcher Searcher, fold bool) normalizeWord {
+	return normalizeWord{
+		searcher: searcher,
+		word:     word,
+		fold:     fold,
+	}
+}
+
+// newNormalizeSearcher returns a Searcher that matches the normalized target.
+// Only a literal word is normalized. A regular expression is used as it is,
+// because NFKC turns full-width characters such as （ and ＊ into metacharacters.
+func newNormalizeSearcher(word string, caseSensitive bool, regexpSearch bool, fold bool) normalizeWord {
+	if regexpSearch && word != regexp.QuoteMeta(word) {
+		searcher := NewSearcher(word, regexpCompile(word, caseSensitive), caseSensitive, true)
+		return newNormalizeWord(word, searcher, fold)
+	}
+	nword := normalizeWordString(word, fold)
+	searcher := NewSearcher(nword, regexpCompile(regexp.QuoteMeta(nword), caseSensitive), caseSensitive, false)
+	return newNormalizeWord(word, searcher, fold)
+}
+
+// normalizeWord Match normalizes the bytes and searches.
+func (substr normalizeWord) Match(target []byte) bool {
+	str, _ := normalizeString(string(target), substr.fold)
+	return substr.searcher.MatchString(str)
+}
+
+// normalizeWord MatchString normalizes the string and searches.
+func (substr normalizeWord) MatchString(target string) bool {
+	str, _ := normalizeString(target, substr.fold)
+	return substr.searcher.MatchString(str)
+}
+
+// normalizeWord FindAll searches the normalized string and
+// returns the index of the match in the original string.
+func (substr normalizeWord) FindAll(target string) [][]int {
+	str, nmap := normalizeString(target, substr.fold)
+	indexes := substr.searcher.FindAll(str)
+	for i, idx := range indexes {
+		indexes[i] = nmap.original(idx[0], idx[1])
+	}
+	return indexes
+}
+
+// normalizeWord String returns the search word before normalization.
+func (substr normalizeWord) String() string {
+	return substr.word
+}
+
+// normalizeMap maps the byte position of the normalized string
+// to the byte range of the original string.
+type normalizeMap struct {
+	start []int
+	end   []int
+}
+
+// original returns the byte range of the original string
+// corresponding to the range of the normalized string.
+func (nmap normalizeMap) original(start int, end int) []int {
+	if len(nmap.start) == 0 {
+		return []int{start, end}
+	}
+	start = min(max(start, 0), len(nmap.start)-1)
+	if end <= start || start >= len(nmap.end) {
+		return []int{nmap.start[start], nmap.start[start]}
+	}
+	end = min(end, len(nmap.end))
+	return []int{nmap.start[start], nmap.end[end-1]}
+}
+
+// normalizeString returns the string normalized by NFKC and
+// the table that maps it to the original string.
+// If fold is true, diacritical marks are also removed.
+func normalizeString(str string, fold bool) (string, normalizeMap) {
+	var buff strings.Builder
+	nmap := normalizeMap{
+		start: make([]int, 0, len(str)),
+		end:   make([]int, 0, len(str)),
+	}
+	var it norm.Iter
+	it.InitString(norm.NFKC, str)
+	for !it.Done() {
+		start := it.Pos()
+		seg := it.Next()
+		end := it.Pos()
+		if fold {
+			seg = foldDiacritics(seg)
+		}
+		for range seg {
+			nmap.start = append(nmap.start, start)
+			nmap.end = append(nmap.end, end)
+		}
+		buff.Write(seg)
+	}
+	nmap.start = append(nmap.start, len(str))
+	return buff.String(), nmap
+}
+
+// normalizeWordString returns the search word normalized in the same way as the target.
+func normalizeWordString(word string, fold bool) string {
+	str, _ := normalizeString(word, fold)
+	return str
+}
+
+// foldDiacritics removes the diacritical marks from the normalized segment.
+func foldDiacritics(seg []byte) []byte {
+	decomposed := norm.NFD.Bytes(seg)
+	folded := make([]byte, 0, len(decomposed))
+	for _, r := range string(decomposed) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		folded = append(folded, string(r)...)
+	}
+	if len(folded) == len(decomposed) {
+		return seg
+	}
+	return norm.NFC.Bytes(folded)
+}
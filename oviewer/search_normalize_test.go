@@ -0,0 +1,136 @@
+package oviewer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_normalizeString(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		str  string
+		fold bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "ascii",
+			args: args{str: "test", fold: false},
+			want: "test",
+		},
+		{
+			name: "fullWidth",
+			args: args{str: "ＡＢＣ", fold: false},
+			want: "ABC",
+		},
+		{
+			name: "halfWidthKatakana",
+			args: args{str: "ｶﾀｶﾅ", fold: false},
+			want: "カタカナ",
+		},
+		{
+			name: "decomposed",
+			args: args{str: "cafe\u0301", fold: false},
+			want: "caf\u00e9",
+		},
+		{
+			name: "fold",
+			args: args{str: "caf\u00e9", fold: true},
+			want: "cafe",
+		},
+		{
+			name: "foldDecomposed",
+			args: args{str: "Cafe\u0301", fold: true},
+			want: "Cafe",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, _ := normalizeString(tt.args.str, tt.args.fold)
+			if got != tt.want {
+				t.Errorf("normalizeString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_normalizeWord_FindAll(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		word          string
+		caseSensitive bool
+		regexpSearch  bool
+		fold          bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		target string
+		want   [][]int
+	}{
+		{
+			name:   "fullWidth",
+			fields: fields{word: "abc", caseSensitive: false, fold: false},
+			target: "xＡＢＣx",
+			want:   [][]int{{1, 10}},
+		},
+		{
+			name:   "fullWidthPattern",
+			fields: fields{word: "ＡＢＣ", caseSensitive: true, fold: false},
+			target: "x ABC",
+			want:   [][]int{{2, 5}},
+		},
+		{
+			name:   "decomposed",
+			fields: fields{word: "caf\u00e9", caseSensitive: true, fold: false},
+			target: "a cafe\u0301 b",
+			want:   [][]int{{2, 8}},
+		},
+		{
+			name:   "fold",
+			fields: fields{word: "cafe", caseSensitive: true, fold: true},
+			target: "caf\u00e9 cafe",
+			want:   [][]int{{0, 5}, {6, 10}},
+		},
+		{
+			name:   "notFold",
+			fields: fields{word: "cafe", caseSensitive: true, fold: false},
+			target: "caf\u00e9 cafe",
+			want:   [][]int{{6, 10}},
+		},
+		{
+			name:   "fullWidthMetacharacters",
+			fields: fields{word: "（a）", caseSensitive: true, regexpSearch: true, fold: false},
+			target: "x (a) y",
+			want:   [][]int{{2, 5}},
+		},
+		{
+			name:   "regexp",
+			fields: fields{word: "a.c", caseSensitive: false, regexpSearch: true, fold: false},
+			target: "xＡＢＣx",
+			want:   [][]int{{1, 10}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			substr := newNormalizeSearcher(tt.fields.word, tt.fields.caseSensitive, tt.fields.regexpSearch, tt.fields.fold)
+			if got := substr.FindAll(tt.target); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("normalizeWord.FindAll() = %v, want %v", got, tt.want)
+			}
+			if !substr.MatchString(tt.target) {
+				t.Errorf("normalizeWord.MatchString() = false, want true")
+			}
+			if !substr.Match([]byte(tt.target)) {
+				t.Errorf("normalizeWord.Match() = false, want true")
+			}
+			if substr.String() != tt.fields.word {
+				t.Errorf("normalizeWord.String() = %v, want %v", substr.String(), tt.fields.word)
+			}
+		})
+	}
+}
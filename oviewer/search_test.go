@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"codeberg.org/tslocum/cbind"
 	"github.com/gdamore/tcell/v3"
@@ -798,6 +799,32 @@ func Test_sensitive_FindAll(t *testing.T) {
 			},
 			want: [][]int{{0, 3}},
 		},
+		{
+			name: "testQuotedFound",
+			fields: fields{
+				searchWord:    "foo",
+				searchReg:     nil,
+				caseSensitive: true,
+				regexpSearch:  false,
+			},
+			args: args{
+				s: `"foo bar" baz`,
+			},
+			want: [][]int{{1, 4}},
+		},
+		{
+			name: "testQuotedCSFound",
+			fields: fields{
+				searchWord:    "foo",
+				searchReg:     nil,
+				caseSensitive: false,
+				regexpSearch:  false,
+			},
+			args: args{
+				s: `"Foo" x`,
+			},
+			want: [][]int{{1, 4}},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1072,6 +1099,202 @@ func TestRoot_searchMove(t *testing.T) {
 	}
 }
 
+func TestRoot_searchMoveBackground(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "test3.txt"))
+	root.prepareScreen()
+	ctx := context.Background()
+	root.screenState.Store(ScreenStateReady)
+	root.Config.SearchBackground = true
+	searcher := NewSearcher("1000", regexpCompile("1000", false), false, false)
+	if got := root.searchMove(ctx, true, 0, searcher); got {
+		t.Errorf("Root.searchMove() = %v, want %v", got, false)
+	}
+	if root.searchCancelFunc == nil {
+		t.Error("Root.searchCancelFunc = nil, want the cancel function of the background search")
+	}
+	if root.cancelFunc != nil {
+		t.Error("Root.cancelFunc != nil, want the cancel function of the incremental search untouched")
+	}
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case ev := <-root.Screen.EventQ():
+			if ev, ok := ev.(*eventSearchMove); ok {
+				if ev.ln != 999 || ev.m != root.Doc {
+					t.Errorf("eventSearchMove.ln = %v, want %v of the searched document", ev.ln, 999)
+				}
+				return
+			}
+		case <-timeout:
+			t.Fatal("timeout waiting for eventSearchMove")
+		}
+	}
+}
+
+func TestRoot_event_searchMoveOtherDocument(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "test3.txt"))
+	root.prepareScreen()
+	ctx := context.Background()
+	root.Doc.WaitEOF()
+	other := docFileReadHelper(t, filepath.Join(testdata, "test3.txt"))
+	searcher := NewSearcher("1000", regexpCompile("1000", false), false, false)
+	// The result of the search of the document that is no longer displayed is ignored.
+	root.event(ctx, &eventSearchMove{m: other, ln: 999, searcher: searcher})
+	if root.Doc.topLN != 0 {
+		t.Errorf("eventSearchMove of other document moved topLN = %v, want 0", root.Doc.topLN)
+	}
+	root.event(ctx, &eventSearchMove{m: root.Doc, ln: 999, searcher: searcher})
+	if root.Doc.topLN == 0 {
+		t.Error("eventSearchMove of the current document did not move")
+	}
+}
+
+func TestRoot_searchMoveBackground_notFound(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootFileReadHelper(t, filepath.Join(testdata, "test3.txt"))
+	root.prepareScreen()
+	ctx := context.Background()
+	root.screenState.Store(ScreenStateReady)
+	root.Config.SearchBackground = true
+	searcher := NewSearcher("notfound", regexpCompile("notfound", false), false, false)
+	if got := root.searchMove(ctx, false, 500, searcher); got {
+		t.Errorf("Root.searchMove() = %v, want %v", got, false)
+	}
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case ev := <-root.Screen.EventQ():
+			switch ev := ev.(type) {
+			case *eventSearchMove:
+				t.Fatalf("eventSearchMove.ln = %v, want no move", ev.ln)
+			case *eventSearchProgress:
+				if strings.Contains(ev.msg, ErrNotFound.Error()) {
+					return
+				}
+			}
+		case <-timeout:
+			t.Fatal("timeout waiting for the not found message")
+		}
+	}
+}
+
+func TestRoot_Cancel_backgroundSearch(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	root := rootHelper(t)
+	ctx := context.Background()
+	incCtx := root.cancelRestart(ctx)
+	searchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	root.searchCancelFunc = cancel
+	root.Cancel(ctx)
+	if searchCtx.Err() == nil {
+		t.Error("Cancel() did not cancel the background search")
+	}
+	if incCtx.Err() != nil {
+		t.Error("Cancel() canceled the incremental search")
+	}
+}
+
+func TestDocument_searchProgressLines(t *testing.T) {
+	t.Parallel()
+	m := docFileReadHelper(t, filepath.Join(testdata, "test3.txt"))
+	total := m.BufEndNum()
+	tests := []struct {
+		name         string
+		forward      bool
+		lineNum      int
+		current      int
+		wantSearched int
+		wantTotal    int
+	}{
+		{
+			name:         "forward",
+			forward:      true,
+			lineNum:      100,
+			current:      300,
+			wantSearched: 200,
+			wantTotal:    total - 100,
+		},
+		{
+			name:         "backward",
+			forward:      false,
+			lineNum:      100,
+			current:      40,
+			wantSearched: 60,
+			wantTotal:    101,
+		},
+		{
+			name:         "backward from the end",
+			forward:      false,
+			lineNum:      total + 10,
+			current:      total - 1,
+			wantSearched: 0,
+			wantTotal:    total,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searched, total := m.searchProgressLines(tt.current, tt.forward, tt.lineNum)
+			if searched != tt.wantSearched || total != tt.wantTotal {
+				t.Errorf("searchProgressLines() = %d, %d, want %d, %d", searched, total, tt.wantSearched, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func Test_searchProgressMessage(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		word    string
+		current int
+		total   int
+		keys    []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "half",
+			args: args{word: "test", current: 500, total: 1000, keys: []string{"ctrl+c"}},
+			want: "search:test 500/1000 (50%) (ctrl+c)Cancel",
+		},
+		{
+			name: "over",
+			args: args{word: "test", current: 1200, total: 1000, keys: []string{"ctrl+c"}},
+			want: "search:test 1000/1000 (100%) (ctrl+c)Cancel",
+		},
+		{
+			name: "empty",
+			args: args{word: "test", current: 0, total: 0, keys: []string{"ctrl+c", "esc"}},
+			want: "search:test 0/0 (100%) (ctrl+c,esc)Cancel",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := searchProgressMessage(tt.args.word, tt.args.current, tt.args.total, tt.args.keys); got != tt.want {
+				t.Errorf("searchProgressMessage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRoot_incSearch(t *testing.T) {
 	tcellNewScreen = fakeScreen
 	defer func() {
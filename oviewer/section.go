@@ -0,0 +1,121 @@
+package oviewer
+
+import (
+	"context"
+	"strings"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// sectionKind is the kind of the sections of the document.
+type sectionKind int
+
+const (
+	// sectionNone is the document without sections.
+	sectionNone sectionKind = iota
+	// sectionDelimiter is the sections by the section delimiter.
+	sectionDelimiter
+	// sectionDiff is the file and hunk headers in the diff mode.
+	sectionDiff
+	// sectionMan is the headings in the man page mode.
+	sectionMan
+	// sectionMarkdown is the headings of the markdown converter.
+	sectionMarkdown
+	// sectionPrompt is the prompt marks of OSC 133.
+	sectionPrompt
+)
+
+// sectionKind returns the kind of the sections of the document.
+// The section delimiter takes precedence, followed by the diff mode, the man page mode,
+// the markdown headings and the prompt marks.
+func (m *Document) sectionKind() sectionKind {
+	switch {
+	case m.SectionDelimiter != "":
+		return sectionDelimiter
+	case m.DiffMode:
+		return sectionDiff
+	case m.ManMode:
+		return sectionMan
+	case m.Converter == convMarkdown:
+		return sectionMarkdown
+	case m.hasPromptMarks():
+		return sectionPrompt
+	}
+	return sectionNone
+}
+
+// sectionEnabled returns true if the document has sections.
+func (m *Document) sectionEnabled() bool {
+	return m.sectionKind() != sectionNone
+}
+
+// sectionSearcher returns the Searcher for the section delimiter.
+// If no section delimiter is set, the file and hunk headers are used in the diff mode,
+// the headings in the man page mode, otherwise the prompt marks of OSC 133.
+// The markdown headings are not searched, they are taken from the scanned document.
+func (m *Document) sectionSearcher() Searcher {
+	switch m.sectionKind() {
+	case sectionDiff:
+		reg := m.diffSectionRegexp()
+		return NewSearcher(reg.String(), reg, true, true)
+	case sectionMan:
+		return NewSearcher(manSectionReg.String(), manSectionReg, true, true)
+	case sectionPrompt:
+		return promptSearcher{}
+	}
+	return NewSearcher(m.SectionDelimiter, m.SectionDelimiterReg, true, true)
+}
+
+// sectionLister returns the function that lists the sections without searching each line.
+// It returns nil if the sections are listed by sectionSearcher.
+func (m *Document) sectionLister() func(context.Context, int) MatchedLineList {
+	switch m.sectionKind() {
+	case sectionDiff:
+		return m.diffSections
+	case sectionMarkdown:
+		return m.markdownSections
+	case sectionPrompt:
+		return m.promptSections
+	}
+	return nil
+}
+
+// isSectionLine returns true if the line is the start of a section.
+func (m *Document) isSectionLine(lN int, lineC LineC) bool {
+	switch m.sectionKind() {
+	case sectionDelimiter:
+		return m.SectionDelimiterReg.MatchString(lineC.str)
+	case sectionDiff:
+		return m.diffSectionRegexp().MatchString(lineC.str)
+	case sectionMan:
+		return manSectionReg.MatchString(lineC.str)
+	case sectionMarkdown:
+		return m.isMarkdownHeading(lN)
+	case sectionPrompt:
+		return m.isPromptLine(lN)
+	}
+	return false
+}
+
+// isPromptLine returns true if the line has the prompt start mark.
+// The escape sequences are parsed only for the lines that contain the marks,
+// and the result is cached until the cache of the document is cleared.
+func (m *Document) isPromptLine(lN int) bool {
+	if m.promptLines == nil {
+		cache, err := lru.New[int, bool](DocumentCacheSize)
+		if err != nil {
+			return false
+		}
+		m.promptLines = cache
+	}
+	if start, ok := m.promptLines.Get(lN); ok {
+		return start
+	}
+	str, err := m.LineStr(lN)
+	if err != nil {
+		return false
+	}
+	start := strings.Contains(str, promptMarkPrefix) && isPromptStart(str)
+	m.promptLines.Add(lN, start)
+	return start
+}
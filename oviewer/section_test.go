@@ -0,0 +1,65 @@
+package oviewer
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDocument_sectionKind(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		delimiter string
+		diff      bool
+		man       bool
+		converter string
+		want      sectionKind
+	}{
+		{name: "none", converter: convEscaped, want: sectionNone},
+		{name: "delimiter", delimiter: "^#", diff: true, converter: convMarkdown, want: sectionDelimiter},
+		{name: "diff", diff: true, man: true, converter: convMarkdown, want: sectionDiff},
+		{name: "man", man: true, converter: convMarkdown, want: sectionMan},
+		{name: "markdown", converter: convMarkdown, want: sectionMarkdown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := docHelper(t, "# a\nb\n")
+			m.SectionDelimiter = tt.delimiter
+			m.DiffMode = tt.diff
+			m.ManMode = tt.man
+			m.Converter = tt.converter
+			if got := m.sectionKind(); got != tt.want {
+				t.Errorf("sectionKind() = %v, want %v", got, tt.want)
+			}
+			if got := m.sectionLister() != nil; got != (tt.want == sectionDiff || tt.want == sectionMarkdown) {
+				t.Errorf("sectionLister() != nil = %v", got)
+			}
+		})
+	}
+}
+
+func TestDocument_isSectionLine_prompt(t *testing.T) {
+	t.Parallel()
+	m := docFileReadHelper(t, filepath.Join(testdata, "prompt.txt"))
+	m.detectPromptMarks()
+	if got := m.sectionKind(); got != sectionPrompt {
+		t.Fatalf("sectionKind() = %v, want %v", got, sectionPrompt)
+	}
+	tests := []struct {
+		lN   int
+		want bool
+	}{
+		{lN: 0, want: true},
+		{lN: 1, want: false},
+		{lN: 2, want: true},
+	}
+	for _, tt := range tests {
+		if got := m.isSectionLine(tt.lN, m.getLineC(tt.lN)); got != tt.want {
+			t.Errorf("isSectionLine(%d) = %v, want %v", tt.lN, got, tt.want)
+		}
+		if got, ok := m.promptLines.Get(tt.lN); !ok || got != tt.want {
+			t.Errorf("promptLines.Get(%d) = %v, %v, want %v, true", tt.lN, got, ok, tt.want)
+		}
+	}
+}
@@ -35,6 +35,12 @@ const (
 	SidebarModeViewMode
 	// SidebarModeStyles is the style list sidebar.
 	SidebarModeStyles
+	// SidebarModeColumnStats is the column statistics sidebar.
+	SidebarModeColumnStats
+	// SidebarModeRecord is the record view of the current row.
+	SidebarModeRecord
+	// SidebarModeLinks is the link list sidebar.
+	SidebarModeLinks
 
 	// SidebarModeEnd marks the end of sidebar modes.
 	SidebarModeEnd
@@ -55,6 +61,12 @@ func (s SidebarMode) String() string {
 		return "View Modes"
 	case SidebarModeStyles:
 		return "Styles"
+	case SidebarModeColumnStats:
+		return "Stats"
+	case SidebarModeRecord:
+		return "Record"
+	case SidebarModeLinks:
+		return "Links"
 	default:
 		return "none"
 	}
@@ -95,6 +107,12 @@ func (root *Root) prepareSidebarItems() {
 		items = root.sidebarItemsForViewMode()
 	case SidebarModeStyles:
 		items = root.sidebarItemsForStyles()
+	case SidebarModeColumnStats:
+		items = root.sidebarItemsForColumnStats()
+	case SidebarModeRecord:
+		items = root.sidebarItemsForRecord()
+	case SidebarModeLinks:
+		items = root.sidebarItemsForLinks()
 	}
 	root.SidebarItems = items
 }
@@ -203,7 +221,7 @@ func (root *Root) sidebarItemsForSections() []SidebarItem {
 		section := sections[i]
 		contents := StrToContents(string(section.line), 0)
 		lc := contents.TrimLeft()
-		numContents := StrToContents(fmt.Sprintf("%d ", section.lineNum), 0)
+		numContents := StrToContents(fmt.Sprintf("%d ", section.lineNum)+strings.Repeat("  ", section.level), 0)
 		lc = append(numContents, lc...)
 		if len(lc) < length {
 			spaces := StrToContents(strings.Repeat(" ", length-len(lc)), 0)
@@ -267,7 +285,7 @@ func (root *Root) sidebarItemsForStyles() []SidebarItem {
 	end := min(start+root.scr.vHeight, totalLines)
 	helpEnd := min(end, len(helpLines))
 	for i := start; i < helpEnd; i++ {
-		items = append(items, root.sidebarItemForStyleHelp(helpLines[i], length))
+		items = append(items, root.sidebarItemForText(helpLines[i], length))
 	}
 
 	styleStart := max(start-len(helpLines), 0)
@@ -282,9 +300,9 @@ func (root *Root) sidebarItemsForStyles() []SidebarItem {
 	return items
 }
 
-// sidebarItemForStyleHelp creates a SidebarItem for a help line in the styles sidebar.
-func (root *Root) sidebarItemForStyleHelp(helpLine string, length int) SidebarItem {
-	displayName := StrToContents(helpLine, 0)
+// sidebarItemForText creates a SidebarItem for a line of text without a label, such as a help line.
+func (root *Root) sidebarItemForText(text string, length int) SidebarItem {
+	displayName := StrToContents(text, 0)
 	if len(displayName) < length {
 		spaces := StrToContents(strings.Repeat(" ", length-len(displayName)), 0)
 		displayName = append(displayName, spaces...)
@@ -271,6 +271,7 @@ func TestRoot_sidebarItemsForSections(t *testing.T) {
 		sections []struct {
 			lineNum int
 			line    []byte
+			level   int
 		}
 		topLN    int
 		want     []SidebarItem
@@ -282,6 +283,7 @@ func TestRoot_sidebarItemsForSections(t *testing.T) {
 			sections: []struct {
 				lineNum int
 				line    []byte
+				level   int
 			}{},
 			topLN:    0,
 			want:     []SidebarItem{},
@@ -293,6 +295,7 @@ func TestRoot_sidebarItemsForSections(t *testing.T) {
 			sections: []struct {
 				lineNum int
 				line    []byte
+				level   int
 			}{
 				{lineNum: 0, line: []byte("Section1")},
 			},
@@ -308,6 +311,7 @@ func TestRoot_sidebarItemsForSections(t *testing.T) {
 			sections: []struct {
 				lineNum int
 				line    []byte
+				level   int
 			}{
 				{lineNum: 0, line: []byte("Section1")},
 				{lineNum: 1, line: []byte("Section2")},
@@ -327,6 +331,7 @@ func TestRoot_sidebarItemsForSections(t *testing.T) {
 			sections: []struct {
 				lineNum int
 				line    []byte
+				level   int
 			}{
 				{lineNum: 0, line: []byte("This is a very long section header")},
 			},
@@ -342,6 +347,7 @@ func TestRoot_sidebarItemsForSections(t *testing.T) {
 			sections: []struct {
 				lineNum int
 				line    []byte
+				level   int
 			}{
 				{lineNum: 0, line: []byte("  Section with indent")},
 			},
@@ -351,6 +357,24 @@ func TestRoot_sidebarItemsForSections(t *testing.T) {
 			},
 			wantCurr: 0,
 		},
+		{
+			name: "sections with level",
+			r:    bytes.NewBufferString("Title\nSub\nLine 3"),
+			sections: []struct {
+				lineNum int
+				line    []byte
+				level   int
+			}{
+				{lineNum: 0, line: []byte("Title")},
+				{lineNum: 1, line: []byte("Sub"), level: 1},
+			},
+			topLN: 0,
+			want: []SidebarItem{
+				{Contents: StrToContents("0 Title         ", 0), IsCurrent: true},
+				{Contents: StrToContents("1   Sub         ", 0), IsCurrent: false},
+			},
+			wantCurr: 0,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -372,7 +396,7 @@ func TestRoot_sidebarItemsForSections(t *testing.T) {
 			root.sidebarMode = SidebarModeSections
 			root.Doc.topLN = tt.topLN
 			for _, sec := range tt.sections {
-				root.Doc.sectionList = append(root.Doc.sectionList, MatchedLine{lineNum: sec.lineNum, line: sec.line})
+				root.Doc.sectionList = append(root.Doc.sectionList, MatchedLine{lineNum: sec.lineNum, line: sec.line, level: sec.level})
 			}
 			got := root.sidebarItemsForSections()
 			if len(got) != len(tt.want) {
@@ -0,0 +1,253 @@
+package oviewer
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// sortType represents how to compare the values of the column.
+type sortType int
+
+const (
+	// sortLexical compares the values as strings.
+	sortLexical sortType = iota
+	// sortNumeric compares the values as numbers.
+	sortNumeric
+	// sortHuman compares the values as human-readable sizes (e.g. 1.5K, 2M).
+	sortHuman
+)
+
+// String returns the string representation of the sort type.
+func (t sortType) String() string {
+	switch t {
+	case sortNumeric:
+		return "numeric"
+	case sortHuman:
+		return "human"
+	}
+	return "lexical"
+}
+
+// sortOrder represents the sort order of the column.
+type sortOrder struct {
+	sortType sortType
+	desc     bool
+}
+
+// String returns the string representation of the sort order.
+func (o sortOrder) String() string {
+	if o.desc {
+		return o.sortType.String() + " desc"
+	}
+	return o.sortType.String()
+}
+
+// parseSortOrder parses the sort order such as "numeric desc".
+// The words can also be abbreviated as in sort(1) (n, h, r).
+func parseSortOrder(input string) (sortOrder, error) {
+	order := sortOrder{}
+	for word := range strings.FieldsSeq(strings.ToLower(input)) {
+		switch strings.TrimLeft(word, "-") {
+		case "lexical", "l":
+			order.sortType = sortLexical
+		case "numeric", "n":
+			order.sortType = sortNumeric
+		case "human", "h":
+			order.sortType = sortHuman
+		case "asc":
+			order.desc = false
+		case "desc", "r":
+			order.desc = true
+		default:
+			return order, fmt.Errorf("%w: %s", ErrInvalidSortOrder, word)
+		}
+	}
+	return order, nil
+}
+
+// sortLine is a line to be sorted.
+type sortLine struct {
+	lN int
+	// line is the line to write, kept so that the evicted chunks do not have to be read again.
+	line  []byte
+	value string
+	num   float64
+	isNum bool
+}
+
+// newSortLine returns the sortLine with the value parsed according to the sort type.
+func newSortLine(lN int, value string, t sortType) sortLine {
+	sl := sortLine{lN: lN, value: trimColumnValue(value)}
+	switch t {
+	case sortNumeric:
+		sl.num, sl.isNum = parseNumber(sl.value)
+	case sortHuman:
+		sl.num, sl.isNum = parseHumanSize(sl.value)
+	}
+	return sl
+}
+
+// compareSortLine compares the lines.
+// Numbers come before values that are not numbers.
+func compareSortLine(a, b sortLine, t sortType) int {
+	if t != sortLexical {
+		switch {
+		case a.isNum && b.isNum:
+			if c := cmp.Compare(a.num, b.num); c != 0 {
+				return c
+			}
+		case a.isNum:
+			return -1
+		case b.isNum:
+			return 1
+		}
+	}
+	return strings.Compare(a.value, b.value)
+}
+
+// sortLines sorts the lines stably in the order.
+func sortLines(lines []sortLine, order sortOrder) {
+	slices.SortStableFunc(lines, func(a, b sortLine) int {
+		if order.desc {
+			return compareSortLine(b, a, order.sortType)
+		}
+		return compareSortLine(a, b, order.sortType)
+	})
+}
+
+// parseNumber parses the number. Thousands separators are ignored.
+func parseNumber(value string) (float64, bool) {
+	value = strings.ReplaceAll(value, ",", "")
+	if value == "" {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// humanSizeUnits is the suffixes of human-readable sizes.
+const humanSizeUnits = "KMGTPE"
+
+// parseHumanSize parses the human-readable size such as 1.5K, 2MiB or 512B.
+func parseHumanSize(value string) (float64, bool) {
+	s := strings.ToUpper(value)
+	s = strings.TrimSuffix(s, "B")
+	s = strings.TrimSuffix(s, "I")
+	mul := 1.0
+	if s != "" {
+		if i := strings.IndexByte(humanSizeUnits, s[len(s)-1]); i >= 0 {
+			for range i + 1 {
+				mul *= 1024
+			}
+			s = s[:len(s)-1]
+		}
+	}
+	n, ok := parseNumber(strings.TrimSpace(s))
+	if !ok {
+		return 0, false
+	}
+	return n * mul, true
+}
+
+// sortColumn sets the sort order and sorts the current document by the cursor column.
+func (root *Root) sortColumn(ctx context.Context, input string) {
+	order, err := parseSortOrder(input)
+	if err != nil {
+		root.setMessage(err.Error())
+		return
+	}
+	root.sortDocument(ctx, root.Doc.cursorColumn(), order)
+}
+
+// sortDocument creates a new document sorted by the column.
+// The header lines are copied as they are.
+func (root *Root) sortDocument(ctx context.Context, column int, order sortOrder) {
+	m := root.Doc
+	r, w := io.Pipe()
+	render, err := renderDoc(m, r)
+	if err != nil {
+		closeFile(r)
+		closeFile(w)
+		log.Printf("failed to sort document: %v\n", err)
+		return
+	}
+	render.documentType = DocSort
+	render.RunTimeSettings = m.RunTimeSettings
+	render.Caption = fmt.Sprintf("sort:%s %s", m.columnLabel(column), order)
+	render.regexpCompile()
+	root.insertDocument(ctx, root.CurrentDoc, render)
+
+	// Copy the header
+	for ln := range render.firstLine() {
+		line, err := m.Line(ln)
+		if err != nil {
+			log.Printf("failed to get line %d: %v\n", ln, err)
+			break
+		}
+		render.lineNumMap.Store(ln, ln)
+		writeLine(w, line)
+	}
+	sorter := m.columnSorter(column, order)
+	renderStart := render.firstLine()
+	go func() {
+		defer closeFile(w)
+		m.WaitEOFWithTimeout(root.Config.ReadWaitTime)
+		sorter.write(ctx, w, render, renderStart)
+	}()
+	root.setMessagef("sort by %s %s", m.columnLabel(column), order)
+}
+
+// columnSorter sorts the lines of a document by a column
+// with the settings of the document taken on the event loop.
+type columnSorter struct {
+	m        *Document
+	splitter columnSplitter
+	column   int
+	order    sortOrder
+	start    int
+}
+
+// columnSorter returns the columnSorter of the column with the current settings.
+func (m *Document) columnSorter(column int, order sortOrder) columnSorter {
+	return columnSorter{
+		m:        m,
+		splitter: m.columnSplitter(),
+		column:   column,
+		order:    order,
+		start:    m.firstLine(),
+	}
+}
+
+// write writes the lines sorted by the column to w.
+// The lines are mapped to the lines of render from renderLN.
+func (s columnSorter) write(ctx context.Context, w io.Writer, render *Document, renderLN int) {
+	var lines []sortLine
+	err := s.m.eachLine(ctx, s.start, func(lN int, line []byte) bool {
+		value := s.splitter.value(string(line), s.column)
+		sl := newSortLine(lN, value, s.order.sortType)
+		sl.line = bytes.Clone(line)
+		lines = append(lines, sl)
+		return true
+	})
+	if err != nil {
+		log.Printf("failed to sort document: %v\n", err)
+		return
+	}
+	sortLines(lines, s.order)
+
+	for _, sl := range lines {
+		render.lineNumMap.Store(renderLN, sl.lN)
+		writeLine(w, sl.line)
+		renderLN++
+	}
+}
@@ -0,0 +1,236 @@
+package oviewer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gdamore/tcell/v3"
+	"github.com/noborus/ov/biomap"
+)
+
+func Test_parseSortOrder(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		want    sortOrder
+		wantErr error
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  sortOrder{sortType: sortLexical},
+		},
+		{
+			name:  "numeric desc",
+			input: "numeric desc",
+			want:  sortOrder{sortType: sortNumeric, desc: true},
+		},
+		{
+			name:  "sort flags",
+			input: "-h -r",
+			want:  sortOrder{sortType: sortHuman, desc: true},
+		},
+		{
+			name:    "invalid",
+			input:   "random",
+			wantErr: ErrInvalidSortOrder,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseSortOrder(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseSortOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got != tt.want {
+				t.Errorf("parseSortOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseHumanSize(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		value  string
+		want   float64
+		wantOK bool
+	}{
+		{value: "512", want: 512, wantOK: true},
+		{value: "512B", want: 512, wantOK: true},
+		{value: "1.5K", want: 1536, wantOK: true},
+		{value: "2MiB", want: 2 * 1024 * 1024, wantOK: true},
+		{value: "1g", want: 1024 * 1024 * 1024, wantOK: true},
+		{value: "-", want: 0, wantOK: false},
+		{value: "", want: 0, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+			got, ok := parseHumanSize(tt.value)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseHumanSize() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRoot_sortDocument(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	tests := []struct {
+		name      string
+		column    int
+		order     sortOrder
+		wantLines []string
+		wantMap   map[int]int
+	}{
+		{
+			name:   "lexical",
+			column: 0,
+			order:  sortOrder{sortType: sortLexical},
+			wantLines: []string{
+				"name,size,count",
+				"a,2M,100",
+				"b,1.5K,10",
+				"c,-,9",
+				"d,512,2",
+			},
+			wantMap: map[int]int{0: 0, 1: 3, 4: 2},
+		},
+		{
+			name:   "numeric desc",
+			column: 2,
+			order:  sortOrder{sortType: sortNumeric, desc: true},
+			wantLines: []string{
+				"name,size,count",
+				"a,2M,100",
+				"b,1.5K,10",
+				"c,-,9",
+				"d,512,2",
+			},
+			wantMap: map[int]int{1: 3, 4: 2},
+		},
+		{
+			name:   "human",
+			column: 1,
+			order:  sortOrder{sortType: sortHuman},
+			wantLines: []string{
+				"name,size,count",
+				"d,512,2",
+				"b,1.5K,10",
+				"a,2M,100",
+				"c,-,9",
+			},
+			wantMap: map[int]int{1: 2, 4: 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := rootFileReadHelper(t, filepath.Join(testdata, "sort.csv"))
+			root.Doc.WaitEOF()
+			root.Doc.ColumnMode = true
+			root.Doc.ColumnDelimiter = ","
+			root.Doc.Header = 1
+			root.Doc.regexpCompile()
+			root.sortDocument(context.Background(), tt.column, tt.order)
+			if root.DocumentLen() != 2 {
+				t.Fatalf("sortDocument() documents = %v, want 2", root.DocumentLen())
+			}
+			doc := root.DocList[len(root.DocList)-1]
+			doc.WaitEOF()
+			if doc.documentType != DocSort || doc.Header != 1 {
+				t.Errorf("sortDocument() type = %v, header = %v", doc.documentType, doc.Header)
+			}
+			for lN, want := range tt.wantLines {
+				line, err := doc.Line(lN)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(line) != want {
+					t.Errorf("sortDocument() line %d = %q, want %q", lN, line, want)
+				}
+			}
+			for renderLN, want := range tt.wantMap {
+				if n, ok := doc.lineNumMap.LoadForward(renderLN); !ok || n != want {
+					t.Errorf("sortDocument() lineNumMap[%d] = %v, want %v", renderLN, n, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRoot_sortDocument_memoryLimit(t *testing.T) {
+	tcellNewScreen = fakeScreen
+	defer func() {
+		tcellNewScreen = tcell.NewScreen
+	}()
+	memoryLimitFile := MemoryLimitFile
+	MemoryLimitFile = 2
+	defer func() {
+		MemoryLimitFile = memoryLimitFile
+	}()
+	num := ChunkSize*4 + 10
+	var buf bytes.Buffer
+	for i := num; i > 0; i-- {
+		fmt.Fprintf(&buf, "%d,x\n", i)
+	}
+	fileName := filepath.Join(t.TempDir(), "large.csv")
+	if err := os.WriteFile(fileName, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	root := rootFileReadHelper(t, fileName)
+	m := root.Doc
+	m.ColumnMode = true
+	m.ColumnDelimiter = ","
+	m.regexpCompile()
+	root.sortDocument(context.Background(), 0, sortOrder{sortType: sortNumeric})
+	doc := root.DocList[len(root.DocList)-1]
+	doc.WaitEOF()
+	if got := doc.BufEndNum(); got != num {
+		t.Fatalf("sortDocument() lines = %d, want %d", got, num)
+	}
+	for _, lN := range []int{0, num - 1} {
+		line, err := doc.Line(lN)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := fmt.Sprintf("%d,x", lN+1); string(line) != want {
+			t.Errorf("sortDocument() line %d = %q, want %q", lN, line, want)
+		}
+	}
+}
+
+func TestDocument_columnSorter(t *testing.T) {
+	t.Parallel()
+	m := docFileReadHelper(t, filepath.Join(testdata, "sort.csv"))
+	m.ColumnMode = true
+	m.ColumnDelimiter = ","
+	m.Header = 1
+	m.regexpCompile()
+	sorter := m.columnSorter(1, sortOrder{sortType: sortHuman})
+	// The settings changed after the sorter is taken are not used.
+	m.ColumnDelimiter = "|"
+	m.regexpCompile()
+
+	render := docHelper(t, "")
+	render.lineNumMap = biomap.NewMap[int, int]()
+	var buf bytes.Buffer
+	sorter.write(context.Background(), &buf, render, 1)
+	want := "d,512,2\nb,1.5K,10\na,2M,100\nc,-,9\n"
+	if got := buf.String(); got != want {
+		t.Errorf("columnSorter.write() = %q, want %q", got, want)
+	}
+	if n, ok := render.lineNumMap.LoadForward(1); !ok || n != 2 {
+		t.Errorf("columnSorter.write() lineNumMap[1] = %v, want 2", n)
+	}
+}
@@ -79,6 +79,12 @@ func (root *Root) StrLeftStatus(numVisible bool) string {
 		leftStatus.WriteString("||")
 	}
 	leftStatus.WriteString(root.statusMode())
+	if root.Doc.preprocessed.Load() {
+		leftStatus.WriteString("(Preprocessed)")
+	}
+	if format := root.Doc.detectedFormat; root.Doc.formatApplied && format != "" && format != formatPlain {
+		leftStatus.WriteString("(" + format + ")")
+	}
 	leftStatus.WriteString(root.displayTitle())
 	leftStatus.WriteString(":")
 	leftStatus.WriteString(root.message)
@@ -142,6 +148,9 @@ func (root *Root) drawRightStatus() {
 	if atomic.LoadInt32(&root.Doc.tmpFollow) == 1 {
 		numStr = fmt.Sprintf("(?/%d%s)", root.Doc.storeEndNum(), next)
 	}
+	if column := root.columnStatus(); column != "" {
+		numStr = column + " " + numStr
+	}
 	numWidth := uniseg.StringWidth(numStr)
 	style := applyStyle(tcell.StyleDefault, root.Doc.Style.RightStatus)
 	root.Screen.PutStrStyled(root.scr.vWidth-numWidth, root.Doc.statusPos, numStr, style)
@@ -345,6 +345,31 @@ func (s *store) joinLast(chunk *chunk, line []byte) bool {
 	return true
 }
 
+// truncate removes the lines from end.
+// It is only used for the documents whose lines are written directly, not read by a reader.
+func (s *store) truncate(end int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if end >= int(atomic.LoadInt32(&s.endNum)) {
+		return
+	}
+	chunkNum, n := chunkLineNum(end)
+	for i := chunkNum; i < len(s.chunks); i++ {
+		chunk := s.chunks[i]
+		from := 0
+		if i == chunkNum {
+			from = min(n, len(chunk.lines))
+		}
+		for _, line := range chunk.lines[from:] {
+			s.size -= int64(len(line))
+		}
+		chunk.lines = chunk.lines[:from]
+	}
+	s.chunks = s.chunks[:chunkNum+1]
+	s.offset = s.size
+	atomic.StoreInt32(&s.endNum, int32(end))
+}
+
 // appendFormFeed appends a formfeed to the chunk.
 func (s *store) appendFormFeed(chunk *chunk) {
 	line := ""
@@ -0,0 +1,330 @@
+package oviewer
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// SyntaxAuto is the Syntax value that selects the language by the extension of the file name.
+const SyntaxAuto = "auto"
+
+// syntaxKind is the kind of the token of the syntax highlighting.
+type syntaxKind int
+
+const (
+	syntaxKeyword syntaxKind = iota
+	syntaxString
+	syntaxNumber
+	syntaxComment
+	syntaxKey
+)
+
+// syntaxToken is a highlighted range [start:end] of the line string.
+type syntaxToken struct {
+	start int
+	end   int
+	kind  syntaxKind
+}
+
+// syntaxLanguage is the definition of the built-in lexer of a language.
+type syntaxLanguage struct {
+	name       string
+	extensions []string
+	keywords   map[string]bool
+	// ignoreCase is true if the keywords are case-insensitive.
+	ignoreCase bool
+	// lineComments are the prefixes of the comments that continue to the end of the line.
+	lineComments []string
+	// blockComment is the start and end of the block comment.
+	blockComment [2]string
+	// quotes are the characters that enclose the strings.
+	quotes string
+	// stringKeys is true if a string followed by a colon is a key.
+	stringKeys bool
+	// keyReg matches the key at the beginning of the line in the first non-empty group.
+	keyReg *regexp.Regexp
+}
+
+// syntaxWords returns the set of the words.
+func syntaxWords(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
+
+// syntaxLanguages is the list of the built-in languages.
+var syntaxLanguages = []*syntaxLanguage{
+	{
+		name:       "go",
+		extensions: []string{".go"},
+		keywords: syntaxWords(`break case chan const continue default defer else fallthrough for func go goto
+			if import interface map package range return select struct switch type var true false nil iota`),
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "\"'`",
+	},
+	{
+		name:       "c",
+		extensions: []string{".c", ".h", ".cc", ".cpp", ".cxx", ".hpp"},
+		keywords: syntaxWords(`auto bool break case char class const continue default delete do double else enum
+			extern false float for goto if inline int long namespace new nullptr private protected public
+			register return short signed sizeof static struct switch template this true typedef typename
+			union unsigned using virtual void volatile while NULL`),
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "\"'",
+	},
+	{
+		name:       "javascript",
+		extensions: []string{".js", ".mjs", ".cjs", ".jsx", ".ts", ".tsx"},
+		keywords: syntaxWords(`async await break case catch class const continue debugger default delete do else
+			enum export extends false finally for function if implements import in instanceof interface let
+			new null of return static super switch this throw true try type typeof undefined var void while yield`),
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "\"'`",
+	},
+	{
+		name:       "python",
+		extensions: []string{".py"},
+		keywords: syntaxWords(`and as assert async await break class continue def del elif else except finally
+			for from global if import in is lambda nonlocal not or pass raise return try while with yield
+			True False None`),
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+	},
+	{
+		name:       "rust",
+		extensions: []string{".rs"},
+		keywords: syntaxWords(`as async await break const continue crate dyn else enum extern false fn for if impl
+			in let loop match mod move mut pub ref return self Self static struct super trait true type unsafe
+			use where while`),
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "\"",
+	},
+	{
+		name:       "sh",
+		extensions: []string{".sh", ".bash", ".zsh"},
+		keywords: syntaxWords(`if then else elif fi case esac for while until do done in function select time
+			return local export readonly break continue exit`),
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+	},
+	{
+		name:       "sql",
+		extensions: []string{".sql"},
+		keywords: syntaxWords(`select from where and or not insert into values update set delete create table drop
+			alter index view join left right inner outer full cross on as group by order having limit offset
+			union all distinct null is in exists between like case when then else end primary key foreign
+			references default begin commit rollback with returning true false asc desc`),
+		ignoreCase:   true,
+		lineComments: []string{"--"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "'",
+	},
+	{
+		name:         "yaml",
+		extensions:   []string{".yaml", ".yml"},
+		keywords:     syntaxWords(`true false null`),
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+		keyReg:       regexp.MustCompile(`^\s*(?:-\s+)*([^\s#'"\-?:,\[\]{}][^#]*?)\s*:(?:\s|$)`),
+	},
+	{
+		name:       "json",
+		extensions: []string{".json", ".jsonl", ".ndjson"},
+		keywords:   syntaxWords(`true false null`),
+		quotes:     "\"",
+		stringKeys: true,
+	},
+	{
+		name:         "toml",
+		extensions:   []string{".toml"},
+		keywords:     syntaxWords(`true false`),
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+		keyReg:       regexp.MustCompile(`^\s*(?:(\[[^\]]*\]\]?)\s*(?:#.*)?$|([\w.-]+)\s*=)`),
+	},
+}
+
+// syntaxCompressExtensions are the extensions of the compressed files that are skipped to find the language.
+var syntaxCompressExtensions = []string{".gz", ".bz2", ".xz", ".zst", ".lz4"}
+
+// lookupSyntax returns the language of the name.
+// The name is the name of the language or its extension.
+// If the name is SyntaxAuto, the language is selected by the extension of the file name.
+func lookupSyntax(name string, fileName string) *syntaxLanguage {
+	if name == "" {
+		return nil
+	}
+	ext := "." + strings.TrimPrefix(strings.ToLower(name), ".")
+	if name == SyntaxAuto {
+		base := strings.ToLower(filepath.Base(fileName))
+		for _, c := range syntaxCompressExtensions {
+			base = strings.TrimSuffix(base, c)
+		}
+		ext = filepath.Ext(base)
+		name = ""
+	}
+	for _, lang := range syntaxLanguages {
+		if strings.EqualFold(lang.name, name) {
+			return lang
+		}
+		for _, e := range lang.extensions {
+			if e == ext {
+				return lang
+			}
+		}
+	}
+	return nil
+}
+
+// SyntaxNames returns the names of the built-in languages.
+func SyntaxNames() []string {
+	names := make([]string, 0, len(syntaxLanguages))
+	for _, lang := range syntaxLanguages {
+		names = append(names, lang.name)
+	}
+	return names
+}
+
+// tokens returns the highlighted tokens of the line.
+// Each line is lexed on its own, so constructs that span lines are highlighted only on their first line.
+func (lang *syntaxLanguage) tokens(str string) []syntaxToken {
+	var tokens []syntaxToken
+	i := 0
+	if lang.keyReg != nil {
+		if match := lang.keyReg.FindStringSubmatchIndex(str); match != nil {
+			for g := 2; g < len(match); g += 2 {
+				if match[g] >= 0 {
+					tokens = append(tokens, syntaxToken{start: match[g], end: match[g+1], kind: syntaxKey})
+					i = match[g+1]
+					break
+				}
+			}
+		}
+	}
+	for i < len(str) {
+		if end, ok := lang.comment(str, i); ok {
+			tokens = append(tokens, syntaxToken{start: i, end: end, kind: syntaxComment})
+			i = end
+			continue
+		}
+		b := str[i]
+		prevWord := i > 0 && isWordByte(str[i-1])
+		switch {
+		case strings.IndexByte(lang.quotes, b) >= 0 && !prevWord:
+			end := syntaxStringEnd(str, i)
+			kind := syntaxString
+			if lang.stringKeys && strings.HasPrefix(strings.TrimLeft(str[end:], " \t"), ":") {
+				kind = syntaxKey
+			}
+			tokens = append(tokens, syntaxToken{start: i, end: end, kind: kind})
+			i = end
+		case b >= '0' && b <= '9' && !prevWord:
+			end := i + 1
+			for end < len(str) && (isWordByte(str[end]) || str[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, syntaxToken{start: i, end: end, kind: syntaxNumber})
+			i = end
+		case isWordByte(b):
+			end := i + 1
+			for end < len(str) && isWordByte(str[end]) {
+				end++
+			}
+			if lang.isKeyword(str[i:end]) && !prevWord {
+				tokens = append(tokens, syntaxToken{start: i, end: end, kind: syntaxKeyword})
+			}
+			i = end
+		default:
+			i++
+		}
+	}
+	return tokens
+}
+
+// comment returns the end of the comment that starts at i.
+func (lang *syntaxLanguage) comment(str string, i int) (int, bool) {
+	for _, c := range lang.lineComments {
+		if !strings.HasPrefix(str[i:], c) {
+			continue
+		}
+		// "#" starts a comment only at the beginning of a word, such as "a#b" in the shell.
+		if c == "#" && i > 0 && !isSpaceByte(str[i-1]) {
+			continue
+		}
+		return len(str), true
+	}
+	start, end := lang.blockComment[0], lang.blockComment[1]
+	if start == "" || !strings.HasPrefix(str[i:], start) {
+		return 0, false
+	}
+	if n := strings.Index(str[i+len(start):], end); n >= 0 {
+		return i + len(start) + n + len(end), true
+	}
+	return len(str), true
+}
+
+// isKeyword returns true if the word is a keyword.
+func (lang *syntaxLanguage) isKeyword(word string) bool {
+	if lang.ignoreCase {
+		word = strings.ToLower(word)
+	}
+	return lang.keywords[word]
+}
+
+// syntaxStringEnd returns the end of the string that starts with the quote at i.
+// The unterminated string continues to the end of the line.
+func syntaxStringEnd(str string, i int) int {
+	quote := str[i]
+	for j := i + 1; j < len(str); j++ {
+		switch str[j] {
+		case '\\':
+			if quote != '`' {
+				j++
+			}
+		case quote:
+			return j + 1
+		}
+	}
+	return len(str)
+}
+
+// syntaxStyle returns the style of the kind.
+func (m *Document) syntaxStyle(kind syntaxKind) OVStyle {
+	switch kind {
+	case syntaxKeyword:
+		return m.Style.SyntaxKeyword
+	case syntaxString:
+		return m.Style.SyntaxString
+	case syntaxNumber:
+		return m.Style.SyntaxNumber
+	case syntaxComment:
+		return m.Style.SyntaxComment
+	case syntaxKey:
+		return m.Style.SyntaxKey
+	}
+	return OVStyle{}
+}
+
+// syntaxHighlight applies the styles of the syntax highlighting to the line.
+// It is applied only to the lines to be drawn.
+func (root *Root) syntaxHighlight(lineC LineC) {
+	m := root.Doc
+	// The json and markdown converters apply their own styles.
+	if m.Converter == convJSON || m.Converter == convMarkdown {
+		return
+	}
+	lang := lookupSyntax(m.Syntax, m.FileName)
+	if lang == nil {
+		return
+	}
+	for _, t := range lang.tokens(lineC.str) {
+		RangeStyle(lineC.lc, lineC.pos.x(t.start), lineC.pos.x(t.end), m.syntaxStyle(t.kind))
+	}
+}
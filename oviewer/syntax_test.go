@@ -0,0 +1,142 @@
+package oviewer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_lookupSyntax(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		syntax   string
+		fileName string
+		want     string
+	}{
+		{name: "off", syntax: "", fileName: "main.go", want: ""},
+		{name: "name", syntax: "go", fileName: "README.md", want: "go"},
+		{name: "name ignore case", syntax: "SQL", fileName: "", want: "sql"},
+		{name: "extension", syntax: "yml", fileName: "", want: "yaml"},
+		{name: "auto", syntax: SyntaxAuto, fileName: "/tmp/config.toml", want: "toml"},
+		{name: "auto compressed", syntax: SyntaxAuto, fileName: "query.SQL.gz", want: "sql"},
+		{name: "auto unknown", syntax: SyntaxAuto, fileName: "README.md", want: ""},
+		{name: "unknown", syntax: "cobol", fileName: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := ""
+			if lang := lookupSyntax(tt.syntax, tt.fileName); lang != nil {
+				got = lang.name
+			}
+			if got != tt.want {
+				t.Errorf("lookupSyntax() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_syntaxLanguage_tokens(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		lang string
+		str  string
+		want []syntaxToken
+	}{
+		{
+			lang: "go",
+			str:  `func f() { return "a\"b" // done`,
+			want: []syntaxToken{
+				{start: 0, end: 4, kind: syntaxKeyword},
+				{start: 11, end: 17, kind: syntaxKeyword},
+				{start: 18, end: 24, kind: syntaxString},
+				{start: 25, end: 32, kind: syntaxComment},
+			},
+		},
+		{
+			lang: "go",
+			str:  "x2 := 0x1F /* c */ + 1.5",
+			want: []syntaxToken{
+				{start: 6, end: 10, kind: syntaxNumber},
+				{start: 11, end: 18, kind: syntaxComment},
+				{start: 21, end: 24, kind: syntaxNumber},
+			},
+		},
+		{
+			lang: "sh",
+			str:  `echo a#b don't # comment`,
+			want: []syntaxToken{
+				{start: 15, end: 24, kind: syntaxComment},
+			},
+		},
+		{
+			lang: "sql",
+			str:  "SELECT name FROM t WHERE id = 'x' -- c",
+			want: []syntaxToken{
+				{start: 0, end: 6, kind: syntaxKeyword},
+				{start: 12, end: 16, kind: syntaxKeyword},
+				{start: 19, end: 24, kind: syntaxKeyword},
+				{start: 30, end: 33, kind: syntaxString},
+				{start: 34, end: 38, kind: syntaxComment},
+			},
+		},
+		{
+			lang: "yaml",
+			str:  "  - name: true # c",
+			want: []syntaxToken{
+				{start: 4, end: 8, kind: syntaxKey},
+				{start: 10, end: 14, kind: syntaxKeyword},
+				{start: 15, end: 18, kind: syntaxComment},
+			},
+		},
+		{
+			lang: "json",
+			str:  `{"a": "b", "n": 10}`,
+			want: []syntaxToken{
+				{start: 1, end: 4, kind: syntaxKey},
+				{start: 6, end: 9, kind: syntaxString},
+				{start: 11, end: 14, kind: syntaxKey},
+				{start: 16, end: 18, kind: syntaxNumber},
+			},
+		},
+		{
+			lang: "toml",
+			str:  "[server]",
+			want: []syntaxToken{
+				{start: 0, end: 8, kind: syntaxKey},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang+" "+tt.str, func(t *testing.T) {
+			t.Parallel()
+			lang := lookupSyntax(tt.lang, "")
+			if got := lang.tokens(tt.str); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokens() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoot_syntaxHighlight(t *testing.T) {
+	root := rootHelper(t)
+	m := root.Doc
+	m.Syntax = "go"
+	lineC := m.getLineC(0)
+	lineC.str = "func"
+	lineC.lc = StrToContents("func", 8)
+	_, lineC.pos = ContentsToStr(lineC.lc)
+	root.syntaxHighlight(lineC)
+	want := applyStyle(defaultStyle, m.Style.SyntaxKeyword)
+	for x, c := range lineC.lc {
+		if c.style != want {
+			t.Errorf("style[%d] = %v, want %v", x, c.style, want)
+		}
+	}
+	m.Converter = convJSON
+	lineC.lc = StrToContents("func", 8)
+	root.syntaxHighlight(lineC)
+	if lineC.lc[0].style != defaultStyle {
+		t.Errorf("json converter style = %v, want %v", lineC.lc[0].style, defaultStyle)
+	}
+}
@@ -60,12 +60,12 @@ func toLast(list []string, s string) []string {
 	return append(list, s)
 }
 
-// allIndex is a wrapper that returns either a regular expression index or a string index.
+// allIndex is a wrapper that returns either a regular expression index or a delimiter index.
 func allIndex(s string, substr string, reg *regexp.Regexp) [][]int {
 	if reg != nil {
 		return reg.FindAllStringIndex(s, -1)
 	}
-	return allStringIndex(s, substr)
+	return delimiterIndex(s, substr)
 }
 
 // allStringIndex returns all matching string positions.
@@ -79,18 +79,53 @@ func allStringIndex(s string, substr string) [][]int {
 		s = s[pos+width:]
 		result = append(result, []int{pos + offSet, pos + offSet + width})
 		offSet += pos + width
+		pos = strings.Index(s, substr)
+	}
+	return result
+}
 
-		if len(s) > 0 && s[0] == '"' {
-			qpos := strings.Index(s[1:], `"`)
-			s = s[qpos+2:]
-			offSet += qpos + 2
+// delimiterIndex returns all positions of the delimiter that splits the columns.
+// The delimiter in a double-quoted field is not matched,
+// and "" in a double-quoted field is an escaped quote (RFC 4180).
+func delimiterIndex(s string, delimiter string) [][]int {
+	if len(delimiter) == 0 {
+		return nil
+	}
+	var result [][]int
+	width := len(delimiter)
+	for start := 0; start < len(s); {
+		from := start
+		if s[start] == '"' {
+			from = quotedFieldEnd(s, start)
 		}
-
-		pos = strings.Index(s, substr)
+		pos := strings.Index(s[from:], delimiter)
+		if pos == -1 {
+			break
+		}
+		pos += from
+		result = append(result, []int{pos, pos + width})
+		start = pos + width
 	}
 	return result
 }
 
+// quotedFieldEnd returns the position after the closing quote of
+// the double-quoted field that starts at start.
+// If the quote is not closed, it returns the length of s.
+func quotedFieldEnd(s string, start int) int {
+	for i := start + 1; i < len(s); i++ {
+		if s[i] != '"' {
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '"' {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(s)
+}
+
 // writeLine writes a line to w.
 // It adds a newline to the end of the line.
 // It logs write errors.
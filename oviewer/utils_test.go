@@ -480,11 +480,54 @@ func Test_allStringIndex(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name: "testQuoted",
+			args: args{
+				s:      `"foo bar" baz`,
+				substr: "foo",
+			},
+			want: [][]int{
+				{1, 4},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := allStringIndex(tt.args.s, tt.args.substr); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("allStringIndex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_delimiterIndex(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		s         string
+		delimiter string
+	}
+	tests := []struct {
+		name string
+		args args
+		want [][]int
+	}{
+		{
+			name: "test1",
+			args: args{
+				s:         "a,b,c",
+				delimiter: ",",
+			},
+			want: [][]int{
+				{1, 2},
+				{3, 4},
+			},
+		},
 		{
 			name: "testDoubleQuote",
 			args: args{
-				s:      `a,"b,c",d`,
-				substr: ",",
+				s:         `a,"b,c",d`,
+				delimiter: ",",
 			},
 			want: [][]int{
 				{1, 2},
@@ -494,20 +537,51 @@ func Test_allStringIndex(t *testing.T) {
 		{
 			name: "testDoubleQuote2",
 			args: args{
-				s:      `a,"060  ",d`,
-				substr: ",",
+				s:         `a,"060  ",d`,
+				delimiter: ",",
 			},
 			want: [][]int{
 				{1, 2},
 				{9, 10},
 			},
 		},
+		{
+			name: "testQuotedFirst",
+			args: args{
+				s:         `"a,b",c`,
+				delimiter: ",",
+			},
+			want: [][]int{
+				{5, 6},
+			},
+		},
+		{
+			name: "testEscapedQuote",
+			args: args{
+				s:         `a,"b"",c",d`,
+				delimiter: ",",
+			},
+			want: [][]int{
+				{1, 2},
+				{9, 10},
+			},
+		},
+		{
+			name: "testUnterminatedQuote",
+			args: args{
+				s:         `a,"b,c`,
+				delimiter: ",",
+			},
+			want: [][]int{
+				{1, 2},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if got := allStringIndex(tt.args.s, tt.args.substr); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("allIndex() = %v, want %v", got, tt.want)
+			if got := delimiterIndex(tt.args.s, tt.args.delimiter); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("delimiterIndex() = %v, want %v", got, tt.want)
 			}
 		})
 	}
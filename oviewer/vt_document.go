@@ -0,0 +1,378 @@
+package oviewer
+
+import (
+	"context"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v3"
+	"github.com/gdamore/tcell/v3/color"
+	"github.com/gdamore/tcell/v3/vt"
+	"github.com/noborus/ov/biomap"
+	"github.com/noborus/tcellansi"
+)
+
+// vtBackend is the screen of the virtual terminal that keeps the scrolled out lines.
+// It implements vt.Backend and vt.Blitter.
+type vtBackend struct {
+	rows [][]vt.Cell
+	// origins are the line numbers of the source lines last written to the rows, or -1.
+	origins []int
+	// srcLN is the line number of the source line being written.
+	srcLN int
+	size  vt.Coord
+	pos   vt.Coord
+	modes map[vt.PrivateMode]vt.ModeStatus
+	// scrollOut is called with the line scrolled out of the top of the screen and its origin.
+	scrollOut func(line []vt.Cell, origin int)
+}
+
+// newVTBackend returns a vtBackend of the size.
+func newVTBackend(width int, height int, scrollOut func([]vt.Cell, int)) *vtBackend {
+	be := &vtBackend{
+		size:      vt.Coord{X: vt.Col(width), Y: vt.Row(height)},
+		scrollOut: scrollOut,
+		modes: map[vt.PrivateMode]vt.ModeStatus{
+			vt.PmShowCursor:       vt.ModeOn,
+			vt.PmBlinkCursor:      vt.ModeOn,
+			vt.PmGraphemeClusters: vt.ModeOff,
+			vt.PmSyncOutput:       vt.ModeOff,
+		},
+	}
+	be.rows = make([][]vt.Cell, height)
+	be.origins = make([]int, height)
+	for y := range be.rows {
+		be.rows[y] = be.blankRow()
+		be.origins[y] = -1
+	}
+	return be
+}
+
+// blankRow returns an empty row.
+func (be *vtBackend) blankRow() []vt.Cell {
+	row := make([]vt.Cell, be.size.X)
+	for x := range row {
+		row[x].S = vt.BaseStyle
+	}
+	return row
+}
+
+func (be *vtBackend) GetPrivateMode(pm vt.PrivateMode) vt.ModeStatus {
+	return be.modes[pm]
+}
+
+func (be *vtBackend) SetPrivateMode(pm vt.PrivateMode, status vt.ModeStatus) error {
+	if old := be.modes[pm]; (old == vt.ModeOn || old == vt.ModeOff) && (status == vt.ModeOn || status == vt.ModeOff) {
+		be.modes[pm] = status
+	}
+	return nil
+}
+
+func (be *vtBackend) GetSize() vt.Coord {
+	return be.size
+}
+
+// Colors returns the number of colors. Direct color is supported.
+func (*vtBackend) Colors() int {
+	return 1 << 24
+}
+
+func (be *vtBackend) Put(pos vt.Coord, cell vt.Cell) {
+	if pos.X < 0 || pos.Y < 0 || pos.X >= be.size.X || pos.Y >= be.size.Y {
+		return
+	}
+	row := be.rows[pos.Y]
+	row[pos.X] = cell
+	if cell.C != "" && cell.C != " " {
+		be.origins[pos.Y] = be.srcLN
+	}
+	// A wide character hides the next cell.
+	if cell.W == 2 && pos.X < be.size.X-1 {
+		row[pos.X+1] = vt.Cell{S: cell.S}
+	}
+}
+
+func (be *vtBackend) GetPosition() vt.Coord {
+	return be.pos
+}
+
+func (be *vtBackend) SetPosition(pos vt.Coord) {
+	pos.X = min(be.size.X-1, max(0, pos.X))
+	pos.Y = min(be.size.Y-1, max(0, pos.Y))
+	be.pos = pos
+}
+
+func (be *vtBackend) Reset() {
+	be.pos = vt.Coord{}
+}
+
+func (*vtBackend) RaiseResize() {}
+
+func (*vtBackend) Buffering(bool) {}
+
+func (*vtBackend) SetCursor(vt.CursorStyle) {}
+
+// Blit copies the cells of the rectangle.
+// The top line is passed to scrollOut when the whole width of the screen scrolls up from the top.
+// The origins move with the rows when the whole width is copied.
+func (be *vtBackend) Blit(src, dst, dim vt.Coord) {
+	dim.X = min(dim.X, be.size.X-src.X, be.size.X-dst.X)
+	dim.Y = min(dim.Y, be.size.Y-src.Y, be.size.Y-dst.Y)
+	if dim.X <= 0 || dim.Y <= 0 {
+		return
+	}
+	wholeWidth := src.X == 0 && dst.X == 0 && dim.X == be.size.X
+	if dst.Y == 0 && src.Y == 1 && wholeWidth && be.scrollOut != nil {
+		be.scrollOut(be.rows[0], be.origins[0])
+	}
+	rect := make([][]vt.Cell, dim.Y)
+	for y := range rect {
+		rect[y] = append([]vt.Cell(nil), be.rows[int(src.Y)+y][src.X:src.X+dim.X]...)
+	}
+	for y, cells := range rect {
+		row := be.rows[int(dst.Y)+y]
+		copy(row[dst.X:], cells)
+	}
+	if !wholeWidth {
+		return
+	}
+	origins := append([]int(nil), be.origins[src.Y:int(src.Y)+int(dim.Y)]...)
+	// The rows left behind are cleared by the terminal.
+	for y := src.Y; y < src.Y+dim.Y; y++ {
+		be.origins[y] = -1
+	}
+	copy(be.origins[dst.Y:], origins)
+}
+
+// lastRow returns the last row that is not empty, or -1 if the screen is empty.
+func (be *vtBackend) lastRow() int {
+	for y := len(be.rows) - 1; y >= 0; y-- {
+		if vtCellsString(be.rows[y]) != "" {
+			return y
+		}
+	}
+	return -1
+}
+
+// vtCellsString returns the line of the cells with SGR escape sequences.
+// Trailing spaces without style are removed.
+func vtCellsString(cells []vt.Cell) string {
+	var buf strings.Builder
+	prevStyle := tcell.StyleDefault
+	spaces := 0
+	for x := 0; x < len(cells); x++ {
+		cell := cells[x]
+		str := cell.C
+		if str == "" {
+			str = " "
+		}
+		style := vtStyle(cell.S)
+		if str == " " && style == tcell.StyleDefault && prevStyle == tcell.StyleDefault {
+			spaces++
+			continue
+		}
+		buf.WriteString(strings.Repeat(" ", spaces))
+		spaces = 0
+		if style != prevStyle {
+			if prevStyle != tcell.StyleDefault {
+				buf.WriteString("\x1b[0m")
+			}
+			buf.WriteString(tcellansi.ToAnsi(style))
+			prevStyle = style
+		}
+		buf.WriteString(str)
+		if cell.W == 2 {
+			x++
+		}
+	}
+	if prevStyle != tcell.StyleDefault {
+		buf.WriteString("\x1b[0m")
+	}
+	return buf.String()
+}
+
+// vtStyle converts the style of the virtual terminal to tcell.Style.
+// The default colors of the virtual terminal are converted to the default colors.
+func vtStyle(s vt.Style) tcell.Style {
+	style := tcell.StyleDefault
+	if s == nil {
+		return style
+	}
+	if fg := s.Fg(); fg != color.Silver && fg.Valid() {
+		style = style.Foreground(fg)
+	}
+	if bg := s.Bg(); bg != color.Black && bg.Valid() {
+		style = style.Background(bg)
+	}
+	attr := s.Attr()
+	style = style.Bold(attr&vt.Bold != 0).
+		Blink(attr&vt.Blink != 0).
+		Reverse(attr&vt.Reverse != 0).
+		Dim(attr&vt.Dim != 0).
+		Italic(attr&vt.Italic != 0).
+		StrikeThrough(attr&vt.StrikeThrough != 0)
+	if attr&vt.Underline != 0 {
+		style = style.Underline(true)
+	}
+	return style
+}
+
+// vtLines is the lines that the virtual terminal renders into.
+type vtLines interface {
+	// appendRenderLine appends the line.
+	appendRenderLine(line []byte)
+	// truncateRenderLines removes the lines from end.
+	truncateRenderLines(end int)
+}
+
+// vtRender is the destination of the lines rendered by the virtual terminal.
+type vtRender struct {
+	lines vtLines
+	// lineNumMap maps the rendered lines to the lines of the source document.
+	lineNumMap *biomap.Map[int, int]
+	// follow returns true while the lines added to the source document are to be rendered.
+	follow func() bool
+	// renderLN is the line number of the next rendered line.
+	renderLN int
+	// lastOrigin is the source line of the last rendered line.
+	lastOrigin int
+	// tailLN and tailOrigin are renderLN and lastOrigin before the tail was written.
+	tailLN     int
+	tailOrigin int
+}
+
+// writeRow writes the row and maps it to the source line.
+// The empty rows that no source line has written follow the previous row.
+func (r *vtRender) writeRow(cells []vt.Cell, origin int) {
+	if origin < 0 {
+		origin = r.lastOrigin + 1
+	}
+	if r.lineNumMap != nil {
+		r.lineNumMap.Store(r.renderLN, origin)
+	}
+	r.lines.appendRenderLine([]byte(vtCellsString(cells)))
+	r.renderLN++
+	r.lastOrigin = origin
+}
+
+// writeScreen writes the rows left on the screen.
+func (r *vtRender) writeScreen(be *vtBackend) {
+	for y := range be.lastRow() + 1 {
+		r.writeRow(be.rows[y], be.origins[y])
+	}
+}
+
+// writeTail writes the rows left on the screen as the tail,
+// which is removed by removeTail before the screen changes.
+func (r *vtRender) writeTail(be *vtBackend) {
+	r.tailLN, r.tailOrigin = r.renderLN, r.lastOrigin
+	r.writeScreen(be)
+}
+
+// removeTail removes the tail written by writeTail.
+func (r *vtRender) removeTail() {
+	r.lines.truncateRenderLines(r.tailLN)
+	r.renderLN, r.lastOrigin = r.tailLN, r.tailOrigin
+}
+
+// vtReplay replays the lines of the document through the virtual terminal of the size
+// and writes the rendered lines to dst.
+// The lines scrolled out of the screen are written first, then the lines left on the screen.
+// While dst.follow returns true, the lines added to the document are replayed as well.
+// The lines left on the screen are written as the tail after each pass,
+// and the tail is replaced by the next pass.
+func (m *Document) vtReplay(ctx context.Context, dst *vtRender, width int, height int) error {
+	dst.lastOrigin = -1
+	be := newVTBackend(width, height, dst.writeRow)
+	em := vt.NewEmulator(be)
+	if err := em.Start(); err != nil {
+		return err
+	}
+	defer func() {
+		if err := em.Stop(); err != nil {
+			log.Println(err)
+		}
+	}()
+	// Discard the responses to the queries.
+	go func() {
+		_, _ = io.Copy(io.Discard, em)
+	}()
+
+	// Each line is written on its own to know the source line of the rows.
+	var buf []byte
+	var werr error
+	start := 0
+	for {
+		err := m.eachLine(ctx, start, func(lN int, line []byte) bool {
+			be.srcLN = lN
+			// The terminal does not return the carriage on a line feed.
+			buf = append(append(buf[:0], line...), "\r\n"...)
+			_, werr = em.Write(buf)
+			start = lN + 1
+			return werr == nil
+		})
+		if err != nil {
+			return err
+		}
+		if werr != nil {
+			return werr
+		}
+		if dst.follow == nil || !dst.follow() {
+			break
+		}
+		dst.writeTail(be)
+		select {
+		case <-ctx.Done():
+			return ErrCancel
+		case <-time.After(UpdateInterval):
+		}
+		dst.removeTail()
+	}
+	dst.writeScreen(be)
+	return nil
+}
+
+// vtDocument creates a new document that displays the current document
+// as rendered by the virtual terminal.
+// Carriage returns, cursor movements and erasing are applied,
+// so that the output of progress bars is displayed as the final screen.
+// The rendered lines are mapped to the lines of the current document,
+// and the lines added to it are rendered while it is being read or followed.
+func (root *Root) vtDocument(ctx context.Context) {
+	m := root.Doc
+	// The current document itself is displayed with the default converter.
+	if m.Converter == convVT {
+		m.Converter = convEscaped
+	}
+	render, err := renderDirectDoc(m)
+	if err != nil {
+		log.Printf("failed to render vt: %v\n", err)
+		return
+	}
+	render.documentType = DocVT
+	render.RunTimeSettings = m.RunTimeSettings
+	render.Caption = "vt:" + m.FileName
+	render.Converter = convEscaped
+	render.regexpCompile()
+	root.insertDocument(ctx, root.CurrentDoc, render)
+
+	width, height := root.scr.vWidth, root.scr.vHeight
+	dst := &vtRender{
+		lines:      render,
+		lineNumMap: render.lineNumMap,
+		follow: func() bool {
+			if render.checkClose() || m.checkClose() {
+				return false
+			}
+			return !m.BufEOF() || m.followModeEnabled() || m.followAllEnabled()
+		},
+	}
+	go func() {
+		defer render.renderEOF()
+		if err := m.vtReplay(ctx, dst, width, height); err != nil {
+			log.Printf("failed to render vt: %v\n", err)
+		}
+	}()
+}
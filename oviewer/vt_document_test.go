@@ -0,0 +1,209 @@
+package oviewer
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v3/vt"
+	"github.com/noborus/ov/biomap"
+)
+
+// vtTestLines is the vtLines that keeps the rendered lines.
+type vtTestLines struct {
+	lines []string
+	// removed is the lines before each truncation.
+	removed []string
+}
+
+func (l *vtTestLines) appendRenderLine(line []byte) {
+	l.lines = append(l.lines, string(line))
+}
+
+func (l *vtTestLines) truncateRenderLines(end int) {
+	l.removed = append(l.removed, l.String())
+	l.lines = l.lines[:end]
+}
+
+func (l *vtTestLines) String() string {
+	var sb strings.Builder
+	for _, line := range l.lines {
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+func TestDocument_vtReplay(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		str        string
+		height     int
+		want       string
+		wantOrigin []int
+	}{
+		{
+			name:       "carriage return",
+			str:        "progress 10%\rprogress 100%\ndone\n",
+			height:     5,
+			want:       "progress 100%\ndone\n",
+			wantOrigin: []int{0, 1},
+		},
+		{
+			name:       "cursor up and erase line",
+			str:        "a\nb\n\x1b[1A\x1b[2Kc\n",
+			height:     5,
+			want:       "a\nc\n",
+			wantOrigin: []int{0, 2},
+		},
+		{
+			name:       "scroll",
+			str:        "1\n2\n3\n4\n5\n",
+			height:     3,
+			want:       "1\n2\n3\n4\n5\n",
+			wantOrigin: []int{0, 1, 2, 3, 4},
+		},
+		{
+			name:   "style",
+			str:    "\x1b[31mred\x1b[0m plain\n",
+			height: 3,
+			want:   "\x1b[31mred\x1b[0m plain\n",
+		},
+		{
+			name:       "empty line",
+			str:        "1\n\n3\n4\n",
+			height:     2,
+			want:       "1\n\n3\n4\n",
+			wantOrigin: []int{0, 1, 2, 3},
+		},
+		{
+			name:   "clear screen",
+			str:    "old\n\x1b[2J\x1b[Hnew\n",
+			height: 3,
+			want:   "new\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := docHelper(t, tt.str)
+			lines := &vtTestLines{}
+			dst := &vtRender{lines: lines, lineNumMap: biomap.NewMap[int, int]()}
+			if err := m.vtReplay(context.Background(), dst, 20, tt.height); err != nil {
+				t.Fatal(err)
+			}
+			if got := lines.String(); got != tt.want {
+				t.Errorf("vtReplay() = %q, want %q", got, tt.want)
+			}
+			for renderLN, want := range tt.wantOrigin {
+				if got, ok := dst.lineNumMap.LoadForward(renderLN); !ok || got != want {
+					t.Errorf("lineNumMap(%d) = %d, %v, want %d", renderLN, got, ok, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDocument_vtReplay_follow(t *testing.T) {
+	t.Parallel()
+	m, err := NewDocument()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, w := io.Pipe()
+	if err := m.ControlReader(r, nil); err != nil {
+		t.Fatal(err)
+	}
+	waitLines := func(n int) {
+		for range 100 {
+			if m.BufEndNum() >= n {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("BufEndNum() = %d, want %d", m.BufEndNum(), n)
+	}
+	go writeLine(w, []byte("1"))
+	waitLines(1)
+	lines := &vtTestLines{}
+	calls := 0
+	dst := &vtRender{
+		lines:      lines,
+		lineNumMap: biomap.NewMap[int, int](),
+		follow: func() bool {
+			calls++
+			if calls == 1 {
+				go func() {
+					writeLine(w, []byte("2"))
+					closeFile(w)
+				}()
+				waitLines(2)
+			}
+			return calls == 1
+		},
+	}
+	if err := m.vtReplay(context.Background(), dst, 20, 3); err != nil {
+		t.Fatal(err)
+	}
+	if got := lines.String(); got != "1\n2\n" {
+		t.Errorf("vtReplay() = %q, want %q", got, "1\n2\n")
+	}
+	// The line left on the screen is written while following, and replaced by the next pass.
+	if len(lines.removed) != 1 || lines.removed[0] != "1\n" {
+		t.Errorf("vtReplay() tail = %q, want %q", lines.removed, []string{"1\n"})
+	}
+}
+
+func TestDocument_truncateRenderLines(t *testing.T) {
+	t.Parallel()
+	m, err := renderDirectDoc(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	num := ChunkSize + 10
+	for i := range num {
+		m.appendRenderLine([]byte(strconv.Itoa(i)))
+	}
+	m.truncateRenderLines(ChunkSize - 5)
+	if got := m.BufEndNum(); got != ChunkSize-5 {
+		t.Fatalf("BufEndNum() = %d, want %d", got, ChunkSize-5)
+	}
+	m.appendRenderLine([]byte("new"))
+	m.renderEOF()
+	m.WaitEOF()
+	tests := map[int]string{0: "0", ChunkSize - 6: strconv.Itoa(ChunkSize - 6), ChunkSize - 5: "new"}
+	for lN, want := range tests {
+		line, err := m.Line(lN)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(line) != want {
+			t.Errorf("Line(%d) = %q, want %q", lN, line, want)
+		}
+	}
+	if _, err := m.Line(ChunkSize - 4); err == nil {
+		t.Errorf("Line(%d) error = nil, want error", ChunkSize-4)
+	}
+}
+
+func Test_vtCellsString(t *testing.T) {
+	t.Parallel()
+	cells := []vt.Cell{
+		{C: "a", W: 1, S: vt.BaseStyle},
+		{C: "あ", W: 2, S: vt.BaseStyle},
+		{C: "", W: 0, S: vt.BaseStyle},
+		{C: "b", W: 1, S: vt.BaseStyle},
+		{C: " ", W: 1, S: vt.BaseStyle},
+		{C: " ", W: 1, S: vt.BaseStyle},
+	}
+	if got := vtCellsString(cells); got != "aあb" {
+		t.Errorf("vtCellsString() = %q, want %q", got, "aあb")
+	}
+	if got := strings.TrimSpace(vtCellsString(make([]vt.Cell, 3))); got != "" {
+		t.Errorf("vtCellsString() = %q, want empty", got)
+	}
+}